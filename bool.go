@@ -0,0 +1,83 @@
+package slicetostruct
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+var defaultTrueTokens = []string{"true", "1", "t"}
+var defaultFalseTokens = []string{"false", "0", "f"}
+
+// ConvertBool converts bool and *bool, accepted values are Params.TrueTokens and Params.FalseTokens,
+// field can override them by tag options true= and false=, tokens are separated by |, like
+// `ss:"active,,true=да|yes,false=нет|no"`.
+type ConvertBool struct {
+	params *Params
+}
+
+func (c *ConvertBool) Set(value *ConvertValueParams) error {
+	if value.ReflectValue.Kind() == reflect.Ptr && value.Items[value.Index] == "" {
+		return nil
+	}
+	v, err := parseBool(value.fieldOptions(c.params), value.Items[value.Index])
+	if err != nil {
+		return err
+	}
+	elem(value.ReflectValue).SetBool(v)
+	return nil
+}
+
+func (c *ConvertBool) Format(value *FormatValueParams) (string, error) {
+	v := *value.ReflectValue
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	return formatBool(value.fieldOptions(c.params), v.Bool()), nil
+}
+
+// boolTokens returns accepted tokens of field, from tag options or Params.
+func (o *fieldOptions) boolTokens() ([]string, []string) {
+	trueTokens, falseTokens := defaultTrueTokens, defaultFalseTokens
+	if o.params != nil && len(o.params.TrueTokens) > 0 {
+		trueTokens = o.params.TrueTokens
+	}
+	if o.params != nil && len(o.params.FalseTokens) > 0 {
+		falseTokens = o.params.FalseTokens
+	}
+	if o.trueTokens != nil {
+		trueTokens = o.trueTokens
+	}
+	if o.falseTokens != nil {
+		falseTokens = o.falseTokens
+	}
+	return trueTokens, falseTokens
+}
+
+func parseBool(o *fieldOptions, item string) (bool, error) {
+	trueTokens, falseTokens := o.boolTokens()
+	item = strings.TrimSpace(item)
+	for _, token := range trueTokens {
+		if strings.EqualFold(item, token) {
+			return true, nil
+		}
+	}
+	for _, token := range falseTokens {
+		if strings.EqualFold(item, token) {
+			return false, nil
+		}
+	}
+	return false, errors.Errorf("cant parse bool %q, accepted true = %q, false = %q", item, trueTokens, falseTokens)
+}
+
+func formatBool(o *fieldOptions, v bool) string {
+	trueTokens, falseTokens := o.boolTokens()
+	if v {
+		return trueTokens[0]
+	}
+	return falseTokens[0]
+}
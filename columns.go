@@ -0,0 +1,189 @@
+package slicetostruct
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// columnsPlan is slice or array field with range or re tag, it is mapped to several columns,
+// every column is converted by converter of element type.
+type columnsPlan struct {
+	// indexes of columns, in order of elements
+	columns       []int
+	elemType      reflect.Type
+	elemConverter Converter
+	elemFormatter Formatter
+}
+
+// compileColumns resolves columns of field with range or re tag,
+// range=from:to is range of slice indexes including to, re matches names on fieldNames.
+func (sTS *SliceToStruct[T]) compileColumns(p *plan, fp *fieldPlan, fieldType reflect.Type, tag tagOptions) error {
+	rangeStr, hasRange := tag.option("range")
+	reStr, _ := tag.option("re")
+	if hasRange && reStr != "" {
+		return errors.Wrapf(ErrInvalidTag, "field = %s, range and re can not be used together", fp.name)
+	}
+	if fieldType.Kind() != reflect.Slice && fieldType.Kind() != reflect.Array {
+		return errors.Wrapf(ErrInvalidTag, "field = %s, range and re require slice or array field", fp.name)
+	}
+	if _, ok := tag.option("default"); ok {
+		return errors.Wrapf(ErrInvalidTag, "field = %s, field with range or re can not have default", fp.name)
+	}
+
+	c := &columnsPlan{
+		elemType: fieldType.Elem(),
+	}
+	c.elemConverter, c.elemFormatter = sTS.converters.resolve(c.elemType)
+	if _, ok := tag.option("conv"); ok {
+		c.elemConverter, c.elemFormatter = fp.converter, fp.formatter
+	}
+	if c.elemConverter == nil {
+		return errors.Wrapf(ErrUnsupportedType, "field = %s, type = %s", fp.name, c.elemType)
+	}
+
+	fp.position = p.numFields
+	if hasRange {
+		from, to, err := parseRange(rangeStr)
+		if err != nil {
+			return errors.Wrapf(err, "field = %s", fp.name)
+		}
+		if fieldType.Kind() == reflect.Array && to-from != fieldType.Len() {
+			return errors.Wrapf(ErrInvalidTag, "field = %s, range %s has %d columns, it differs from %s", fp.name, rangeStr, to-from, fieldType)
+		}
+		for i := from; i < to; i++ {
+			if len(sTS.header) > 0 && i >= len(sTS.header) {
+				break
+			}
+			c.columns = append(c.columns, i)
+		}
+		// next fields without fieldNames follow range
+		fp.position = from
+		if p.numFields < to {
+			p.numFields = to
+		}
+	} else {
+		re, err := regexp.Compile(reStr)
+		if err != nil {
+			return errors.Wrapf(ErrInvalidTag, "field = %s, cant regexp.Compile %s, %v", fp.name, reStr, err)
+		}
+		// without fieldNames there are no names to match, field would be always empty
+		if len(sTS.header) == 0 {
+			return errors.Wrapf(ErrInvalidTag, "field = %s, re requires fieldNames", fp.name)
+		}
+		for i := range sTS.header {
+			if re.MatchString(sTS.header[i]) {
+				c.columns = append(c.columns, i)
+			}
+		}
+		if fieldType.Kind() == reflect.Array && len(c.columns) > fieldType.Len() {
+			return errors.Wrapf(ErrInvalidTag, "field = %s, %d columns match %s, it is more than %s", fp.name, len(c.columns), reStr, fieldType)
+		}
+	}
+	if len(c.columns) > 0 && len(sTS.header) > 0 {
+		fp.sliceIndex = c.columns[0]
+	}
+	fp.columns = c
+	return nil
+}
+
+// parseRange parses from:to of range tag, to is included, result is half-open range.
+func parseRange(rangeStr string) (int, int, error) {
+	fromStr, toStr, ok := strings.Cut(rangeStr, ":")
+	if !ok {
+		return 0, 0, errors.Wrapf(ErrInvalidTag, "range %q should be from:to", rangeStr)
+	}
+	from, err := strconv.Atoi(fromStr)
+	if err != nil {
+		return 0, 0, errors.Wrapf(ErrInvalidTag, "range %q should be from:to", rangeStr)
+	}
+	to, err := strconv.Atoi(toStr)
+	if err != nil {
+		return 0, 0, errors.Wrapf(ErrInvalidTag, "range %q should be from:to", rangeStr)
+	}
+	if from < 0 || to < from {
+		return 0, 0, errors.Wrapf(ErrInvalidTag, "range %q is empty", rangeStr)
+	}
+	return from, to + 1, nil
+}
+
+// setColumns converts columns of row to elements of field, empty and null cells are zero elements,
+// slice has element for every column of row.
+func (fp *fieldPlan) setColumns(curStruct reflect.Value, items []string) *FieldError {
+	c := fp.columns
+	var field reflect.Value
+	params := ConvertValueParams{
+		Items:     items,
+		Tags:      fp.tags,
+		FieldName: &fp.sliceName,
+		FieltType: c.elemType.String(),
+		options:   fp.options,
+	}
+	for i, index := range c.columns {
+		item := ""
+		if index < len(items) {
+			item = items[index]
+		}
+		if fp.isNull(item) {
+			if fp.required {
+				return newFieldError(fp, index, item, ErrRequired, errors.New("required value is empty"))
+			}
+			continue
+		}
+		if !field.IsValid() {
+			field = fieldByIndex(curStruct, fp.path)
+			if field.Kind() == reflect.Slice {
+				n := 0
+				for n < len(c.columns) && c.columns[n] < len(items) {
+					n++
+				}
+				field.Set(reflect.MakeSlice(field.Type(), n, n))
+			}
+		}
+		v := field.Index(i)
+		params.Index = index
+		params.ReflectValue = &v
+		err := c.elemConverter.Set(&params)
+		if err != nil {
+			return newFieldError(fp, index, item, ErrParse, errors.Wrapf(err, "cant elemConverter.Set, element = %d", i))
+		}
+	}
+	return nil
+}
+
+// formatColumns renders elements of field to columns of res.
+func (fp *fieldPlan) formatColumns(field reflect.Value, res []string) error {
+	c := fp.columns
+	if c.elemFormatter == nil {
+		return errors.Wrapf(ErrFormatterDoesNotExist, "type = %s. field = %s", c.elemType, fp.sliceName)
+	}
+	params := FormatValueParams{
+		Tags:      fp.tags,
+		FieldName: &fp.sliceName,
+		FieltType: c.elemType.String(),
+		options:   fp.options,
+	}
+	for i, index := range c.columns {
+		if i >= field.Len() || index >= len(res) {
+			break
+		}
+		v := field.Index(i)
+		if fp.omitEmpty && v.IsZero() {
+			continue
+		}
+		if fp.nullOutput != "" && isNullValue(v) {
+			res[index] = fp.nullOutput
+			continue
+		}
+		params.ReflectValue = &v
+		var err error
+		res[index], err = c.elemFormatter.Format(&params)
+		if err != nil {
+			return errors.Wrapf(err, "cant elemFormatter.Format. field = %s, index = %d", fp.sliceName, index)
+		}
+	}
+	return nil
+}
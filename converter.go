@@ -3,6 +3,7 @@ package slicetostruct
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 )
 
@@ -13,17 +14,37 @@ type ConvertValueParams struct {
 	Tags         []string
 	FieldName    *string
 	FieltType    string
+	options      *fieldOptions
 }
 
 type Converter interface {
 	Set(value *ConvertValueParams) error
 }
 
+type FormatValueParams struct {
+	ReflectValue *reflect.Value
+	Tags         []string
+	FieldName    *string
+	FieltType    string
+	options      *fieldOptions
+}
+
+// Formatter is the reverse of Converter, it renders field value to slice item.
+type Formatter interface {
+	Format(value *FormatValueParams) (string, error)
+}
+
 var ErrConverterDoesNotExist = fmt.Errorf("converter does not exist")
+var ErrFormatterDoesNotExist = fmt.Errorf("formatter does not exist")
 
 type converters struct {
 	converters map[string]Converter
-	mu         sync.Mutex
+	types      map[reflect.Type]Converter
+	formatters map[reflect.Type]Formatter
+	// used for named types like `type ID int64`, when converter is not set for type name
+	kinds  map[reflect.Kind]Converter
+	params *Params
+	mu     sync.Mutex
 }
 
 func (converters *converters) SetConverter(name string, converter Converter) {
@@ -44,3 +65,148 @@ func (converters *converters) GetConverter(name string) (Converter, error) {
 	}
 	return converter, nil
 }
+
+func (converters *converters) SetTypeConverter(fieldType reflect.Type, converter Converter) {
+	converters.mu.Lock()
+	defer converters.mu.Unlock()
+	if converters.types == nil {
+		converters.types = make(map[reflect.Type]Converter)
+	}
+	converters.types[fieldType] = converter
+	if formatter, ok := converter.(Formatter); ok {
+		converters.setTypeFormatter(fieldType, formatter)
+	}
+}
+
+func (converters *converters) SetTypeFormatter(fieldType reflect.Type, formatter Formatter) {
+	converters.mu.Lock()
+	defer converters.mu.Unlock()
+	converters.setTypeFormatter(fieldType, formatter)
+}
+
+func (converters *converters) setTypeFormatter(fieldType reflect.Type, formatter Formatter) {
+	if converters.formatters == nil {
+		converters.formatters = make(map[reflect.Type]Formatter)
+	}
+	converters.formatters[fieldType] = formatter
+}
+
+func (converters *converters) GetFormatter(name string) (Formatter, error) {
+	converter, err := converters.GetConverter(name)
+	if err != nil {
+		return nil, ErrFormatterDoesNotExist
+	}
+	formatter, ok := converter.(Formatter)
+	if !ok {
+		return nil, ErrFormatterDoesNotExist
+	}
+	return formatter, nil
+}
+
+// resolve returns converter and formatter for field type, registered by type first, then by name,
+// then encoding.TextUnmarshaler/encoding.TextMarshaler, then sql.Scanner/driver.Valuer,
+// then by underlying kind of type or pointer element, slice is converted by converter of element.
+func (converters *converters) resolve(fieldType reflect.Type) (Converter, Formatter) {
+	converters.mu.Lock()
+	converter := converters.types[fieldType]
+	formatter := converters.formatters[fieldType]
+	converters.mu.Unlock()
+
+	name := fieldType.String()
+	if converter == nil {
+		converter, _ = converters.GetConverter(name)
+	}
+	if formatter == nil {
+		formatter, _ = converters.GetFormatter(name)
+	}
+	if converter == nil {
+		switch {
+		case implements(fieldType, textUnmarshalerType):
+			converter = &ConvertText{}
+		case implements(fieldType, scannerType):
+			converter = &ConvertScanner{params: converters.params}
+		}
+	}
+	if formatter == nil {
+		switch {
+		case implements(fieldType, textMarshalerType):
+			formatter = &ConvertText{}
+		case implements(fieldType, valuerType):
+			formatter = &ConvertScanner{params: converters.params}
+		}
+	}
+
+	kind := fieldType.Kind()
+	if kind == reflect.Ptr {
+		kind = fieldType.Elem().Kind()
+	}
+	if converter == nil {
+		converter = converters.kinds[kind]
+	}
+	if formatter == nil {
+		formatter, _ = converters.kinds[kind].(Formatter)
+	}
+	if converter == nil && fieldType.Kind() == reflect.Slice {
+		elemConverter, elemFormatter := converters.resolve(fieldType.Elem())
+		if elemConverter != nil {
+			convertSlice := &ConvertSlice{
+				elemType:      fieldType.Elem(),
+				elemConverter: elemConverter,
+				elemFormatter: elemFormatter,
+			}
+			converter = convertSlice
+			if formatter == nil && elemFormatter != nil {
+				formatter = convertSlice
+			}
+		}
+	}
+	return converter, formatter
+}
+
+// elem returns value to set, new value is allocated for pointer.
+func elem(value *reflect.Value) reflect.Value {
+	if value.Kind() != reflect.Ptr {
+		return *value
+	}
+	v := reflect.New(value.Type().Elem())
+	value.Set(v)
+	return v.Elem()
+}
+
+// tagOption returns value of key=value option of tag.
+func tagOption(tags []string, key string) (string, bool) {
+	for i := 1; i < len(tags); i++ {
+		if strings.HasPrefix(tags[i], key+"=") {
+			return tags[i][len(key)+1:], true
+		}
+	}
+	return "", false
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex, which allocates nil pointers of nested structs.
+func fieldByIndex(v reflect.Value, path []int) reflect.Value {
+	for i, index := range path {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(index)
+	}
+	return v
+}
+
+// readFieldByIndex is reflect.Value.FieldByIndex, which returns false for nil pointer of nested struct.
+func readFieldByIndex(v reflect.Value, path []int) (reflect.Value, bool) {
+	for i, index := range path {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(index)
+	}
+	return v, true
+}
@@ -0,0 +1,365 @@
+package slicetostruct
+
+import (
+	"database/sql/driver"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// Decimal is exact fixed-point number for money columns, value is coefficient * 10^-scale,
+// zero value is 0. Field of Decimal type is converted with locale and money tag options,
+// tag option scale=2 rounds value to 2 digits after point, see RoundingMode.
+type Decimal struct {
+	coef  *big.Int
+	scale int
+}
+
+// RoundingMode is mode of Decimal.Round, it is set by tag option round=half_even.
+type RoundingMode int
+
+const (
+	// half away from zero, 2.5 is 3, -2.5 is -3
+	RoundHalfUp RoundingMode = iota
+	// half to even, 2.5 is 2, 3.5 is 4
+	RoundHalfEven
+	// half toward zero, 2.5 is 2
+	RoundHalfDown
+	// toward zero
+	RoundDown
+	// away from zero
+	RoundUp
+	// toward negative infinity
+	RoundFloor
+	// toward positive infinity
+	RoundCeiling
+)
+
+var roundingModes = map[string]RoundingMode{
+	"half_up":   RoundHalfUp,
+	"half_even": RoundHalfEven,
+	"half_down": RoundHalfDown,
+	"down":      RoundDown,
+	"up":        RoundUp,
+	"floor":     RoundFloor,
+	"ceiling":   RoundCeiling,
+}
+
+var bigTen = big.NewInt(10)
+
+// NewDecimal returns coef * 10^-scale, like NewDecimal(12345, 2) is 123.45.
+func NewDecimal(coef int64, scale int) Decimal {
+	if scale < 0 {
+		return Decimal{coef: new(big.Int).Mul(big.NewInt(coef), pow10(-scale))}
+	}
+	return Decimal{coef: big.NewInt(coef), scale: scale}
+}
+
+// ParseDecimal parses number like -1234.50, scale of result is count of digits after point.
+func ParseDecimal(s string) (Decimal, error) {
+	item := s
+	negative := false
+	if strings.HasPrefix(item, "-") || strings.HasPrefix(item, "+") {
+		negative = item[0] == '-'
+		item = item[1:]
+	}
+	intPart, frac, _ := strings.Cut(item, ".")
+	digits := intPart + frac
+	if digits == "" {
+		return Decimal{}, errors.Errorf("cant parse decimal %q", s)
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return Decimal{}, errors.Errorf("cant parse decimal %q", s)
+		}
+	}
+	coef, _ := new(big.Int).SetString(digits, 10)
+	if negative {
+		coef.Neg(coef)
+	}
+	return Decimal{coef: coef, scale: len(frac)}, nil
+}
+
+func (d Decimal) coefficient() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// Coefficient returns copy of coefficient, value is Coefficient * 10^-Scale.
+func (d Decimal) Coefficient() *big.Int {
+	return new(big.Int).Set(d.coefficient())
+}
+
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+func (d Decimal) Sign() int {
+	return d.coefficient().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp compares d and other, it returns -1, 0 or +1.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b := d.align(other)
+	return a.Cmp(b)
+}
+
+// Add returns d + other, scale of result is greater scale.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b := d.align(other)
+	scale := d.scale
+	if other.scale > scale {
+		scale = other.scale
+	}
+	return Decimal{coef: new(big.Int).Add(a, b), scale: scale}
+}
+
+// Sub returns d - other, scale of result is greater scale.
+func (d Decimal) Sub(other Decimal) Decimal {
+	return d.Add(Decimal{coef: new(big.Int).Neg(other.coefficient()), scale: other.scale})
+}
+
+// align returns coefficients of d and other with same scale.
+func (d Decimal) align(other Decimal) (*big.Int, *big.Int) {
+	a, b := d.coefficient(), other.coefficient()
+	switch {
+	case d.scale < other.scale:
+		a = new(big.Int).Mul(a, pow10(other.scale-d.scale))
+	case d.scale > other.scale:
+		b = new(big.Int).Mul(b, pow10(d.scale-other.scale))
+	}
+	return a, b
+}
+
+// Round returns d with scale digits after point, rounded by mode, greater scale adds zeros.
+func (d Decimal) Round(scale int, mode RoundingMode) Decimal {
+	coef := d.coefficient()
+	if scale >= d.scale {
+		return Decimal{coef: new(big.Int).Mul(coef, pow10(scale-d.scale)), scale: scale}
+	}
+
+	divisor := pow10(d.scale - scale)
+	q, r := new(big.Int).QuoRem(coef, divisor, new(big.Int))
+	if r.Sign() == 0 {
+		return Decimal{coef: q, scale: scale}
+	}
+	sign := coef.Sign()
+	// half is 2*|r| compared with divisor
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1)
+	cmpHalf := half.Cmp(divisor)
+	increment := false
+	switch mode {
+	case RoundHalfUp:
+		increment = cmpHalf >= 0
+	case RoundHalfEven:
+		increment = cmpHalf > 0 || (cmpHalf == 0 && q.Bit(0) == 1)
+	case RoundHalfDown:
+		increment = cmpHalf > 0
+	case RoundUp:
+		increment = true
+	case RoundFloor:
+		increment = sign < 0
+	case RoundCeiling:
+		increment = sign > 0
+	}
+	if increment {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return Decimal{coef: q, scale: scale}
+}
+
+// Float64 returns nearest float64 of d.
+func (d Decimal) Float64() float64 {
+	v, _ := strconv.ParseFloat(d.String(), 64)
+	return v
+}
+
+// String returns d with Scale digits after point, like -1234.50.
+func (d Decimal) String() string {
+	coef := d.coefficient()
+	digits := new(big.Int).Abs(coef).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if coef.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Scan implements sql.Scanner, NULL is 0.
+func (d *Decimal) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*d = Decimal{}
+		return nil
+	case int64:
+		*d = NewDecimal(src, 0)
+		return nil
+	case float64:
+		return d.UnmarshalText([]byte(strconv.FormatFloat(src, 'f', -1, 64)))
+	case string:
+		return d.UnmarshalText([]byte(src))
+	case []byte:
+		return d.UnmarshalText(src)
+	default:
+		return errors.Errorf("cant scan %T to Decimal", src)
+	}
+}
+
+// Value implements driver.Valuer, value is string to keep precision.
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+var decimalType = reflect.TypeOf(Decimal{})
+
+// ConvertDecimal converts Decimal and *Decimal with locale and money tag options,
+// with tag option scale value is rounded to scale by round tag option,
+// empty cell and null token are zero Decimal and nil *Decimal.
+type ConvertDecimal struct {
+	params *Params
+}
+
+func (c *ConvertDecimal) Set(value *ConvertValueParams) error {
+	if value.Items[value.Index] == "" {
+		// Decimal is sql.Scanner, so empty and null cells are passed as empty string, NULL is 0 like Scan
+		if value.ReflectValue.Kind() != reflect.Ptr {
+			elem(value.ReflectValue).Set(reflect.ValueOf(Decimal{}))
+		}
+		return nil
+	}
+	d, err := parseDecimal(value.fieldOptions(c.params), value.Items[value.Index])
+	if err != nil {
+		return err
+	}
+	elem(value.ReflectValue).Set(reflect.ValueOf(d))
+	return nil
+}
+
+func (c *ConvertDecimal) Format(value *FormatValueParams) (string, error) {
+	v := *value.ReflectValue
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	d := v.Interface().(Decimal)
+	o := value.fieldOptions(c.params)
+	if o.hasScale {
+		d = d.Round(o.scale, o.round)
+	}
+	return formatNumber(o, d.String()), nil
+}
+
+// parseDecimal parses item with locale and money tag options, with scale tag option
+// value is rounded to scale, without round tag option value with more digits is error.
+func parseDecimal(o *fieldOptions, item string) (Decimal, error) {
+	normalized, err := normalizeNumber(o, item)
+	if err != nil {
+		return Decimal{}, err
+	}
+	d, err := ParseDecimal(normalized)
+	if err != nil {
+		return Decimal{}, err
+	}
+	if !o.hasScale {
+		return d, nil
+	}
+	if _, ok := tagOption(o.tags, "round"); !ok && d.scale > o.scale && d.Round(o.scale, RoundDown).Cmp(d) != 0 {
+		return Decimal{}, errors.Errorf("cant parse decimal %q, more than %d digits after point", item, o.scale)
+	}
+	return d.Round(o.scale, o.round), nil
+}
+
+// parseScaled parses item to integer of minor units, like 12.34 with scale=2 is 1234.
+func parseScaled(o *fieldOptions, item string) (*big.Int, error) {
+	d, err := parseDecimal(o, item)
+	if err != nil {
+		return nil, err
+	}
+	return d.coef, nil
+}
+
+// formatScaled formats integer of minor units, like 1234 with scale=2 is 12.34.
+func formatScaled(o *fieldOptions, coef *big.Int) string {
+	return formatNumber(o, Decimal{coef: coef, scale: o.scale}.String())
+}
+
+func decimalScale(tags []string) (int, bool) {
+	v, ok := tagOption(tags, "scale")
+	if !ok {
+		return 0, false
+	}
+	scale, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return scale, true
+}
+
+func roundingMode(tags []string) RoundingMode {
+	v, _ := tagOption(tags, "round")
+	return roundingModes[v]
+}
+
+// compileScale validates scale and round tag options, scale is for Decimal and integer fields.
+func compileScale(fp *fieldPlan, fieldType reflect.Type, tag tagOptions) error {
+	scaleStr, hasScale := tag.option("scale")
+	round, hasRound := tag.option("round")
+	if hasRound && !hasScale {
+		return errors.Wrapf(ErrInvalidTag, "field = %s, round requires scale", fp.name)
+	}
+	if !hasScale {
+		return nil
+	}
+	if scale, err := strconv.Atoi(scaleStr); err != nil || scale < 0 {
+		return errors.Wrapf(ErrInvalidTag, "field = %s, scale %q should be not negative integer", fp.name, scaleStr)
+	}
+	if _, ok := roundingModes[round]; hasRound && !ok {
+		return errors.Wrapf(ErrInvalidTag, "field = %s, unknown round %q", fp.name, round)
+	}
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return nil
+	}
+	if fieldType != decimalType {
+		return errors.Wrapf(ErrInvalidTag, "field = %s, scale requires Decimal or integer field", fp.name)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package slicetostruct
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+type DecoderParams struct {
+	Params
+	// field delimiter, comma by default
+	Comma rune
+	// lines beginning with Comment character are ignored
+	Comment rune
+	// see csv.Reader.LazyQuotes
+	LazyQuotes bool
+}
+
+// Decoder reads csv records and converts each of them to T.
+// If Params.FieldNames is empty the first record is used as fieldNames.
+type Decoder[T any] struct {
+	reader     *csv.Reader
+	sTS        *SliceToStruct[T]
+	readHeader bool
+	value      *T
+	rowErr     error
+	line       int
+	err        error
+}
+
+func NewDecoder[T any](r io.Reader, params DecoderParams) *Decoder[T] {
+	reader := csv.NewReader(r)
+	if params.Comma != 0 {
+		reader.Comma = params.Comma
+	}
+	reader.Comment = params.Comment
+	reader.LazyQuotes = params.LazyQuotes
+	reader.FieldsPerRecord = -1
+
+	return &Decoder[T]{
+		reader:     reader,
+		sTS:        New[T](params.Params),
+		readHeader: len(params.FieldNames) == 0,
+	}
+}
+
+// SliceToStruct returns underlying converter, it can be used for SetConverter.
+func (d *Decoder[T]) SliceToStruct() *SliceToStruct[T] {
+	return d.sTS
+}
+
+// Next reads next record, returns false on io.EOF or error, see Err.
+// With Params.CollectErrors record with invalid fields does not stop decoder, see RowErr.
+func (d *Decoder[T]) Next() bool {
+	if d.err != nil {
+		return false
+	}
+	d.value = nil
+	d.rowErr = nil
+
+	if d.readHeader {
+		d.readHeader = false
+		header, err := d.read()
+		if err != nil {
+			return false
+		}
+		if len(header) > 0 {
+			header[0] = strings.TrimPrefix(header[0], "\ufeff")
+		}
+		err = d.sTS.SetFieldNames(header)
+		if err != nil {
+			d.err = errors.Wrap(err, "cant sTS.SetFieldNames")
+			return false
+		}
+	}
+
+	// header does not match struct, like missing required column, no record can be converted
+	if err := d.sTS.Err(); err != nil {
+		d.err = errors.Wrap(err, "invalid fieldNames")
+		return false
+	}
+
+	record, err := d.read()
+	if err != nil {
+		return false
+	}
+	value, err := d.sTS.ToStruct(record)
+	var rowErr *RowError
+	if value != nil && errors.As(err, &rowErr) {
+		d.value = value
+		d.rowErr = errors.Wrapf(err, "line %d", d.line)
+		return true
+	}
+	if err != nil {
+		d.err = errors.Wrapf(err, "line %d", d.line)
+		return false
+	}
+	d.value = value
+	return true
+}
+
+func (d *Decoder[T]) read() ([]string, error) {
+	record, err := d.reader.Read()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			d.err = errors.Wrap(err, "cant reader.Read")
+		}
+		return nil, err
+	}
+	d.line, _ = d.reader.FieldPos(0)
+	return record, nil
+}
+
+// Value returns struct of last record read by Next.
+func (d *Decoder[T]) Value() *T {
+	return d.value
+}
+
+// RowErr returns RowError of last record read by Next, if Params.CollectErrors is set,
+// Value is partially filled struct of record then.
+func (d *Decoder[T]) RowErr() error {
+	return d.rowErr
+}
+
+// Line returns line number of last record read by Next.
+func (d *Decoder[T]) Line() int {
+	return d.line
+}
+
+// Err returns first error, io.EOF is not error.
+func (d *Decoder[T]) Err() error {
+	return d.err
+}
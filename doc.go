@@ -0,0 +1,45 @@
+// Package slicetostruct converts slice of strings, like csv record, to struct and back.
+//
+// Fields are mapped by ss tag:
+//
+//	ss:"name,option,option"
+//
+// name is column name on fieldNames, "-" skips field, empty name is struct field name.
+// Name and option value may be quoted by ' to contain commas, like ss:"'is, id'", doubled ' is quote
+// inside of quotes, trailing # of name escapes comma too, like ss:"is#, id", but not of key=value option,
+// so ss:"tags,sep=#,omitempty" has separator #.
+// Option is flag or key=value:
+//
+//	omitempty                empty value is not converted, zero value is not rendered by ToSlice
+//	required                 column must exist on fieldNames, empty value is ErrRequired
+//	inline                   fields of struct field are mapped without prefix
+//	prefix=addr_             fields of struct field are mapped with prefix
+//	rest                     map[string]string or []Cell field for items which are not mapped to fields
+//	layout=layout|layout     time layouts tried in order, first is used by ToSlice, default Params.TimeLayouts or 02.01.2006
+//	tz=name                  time location of layout without zone, default UTC
+//	default=value            value of empty or missing cell, converted by same converter
+//	sep=;                    separator of slice field elements, default comma
+//	range=5:16               slice or array field of columns 5 to 16 inclusive, by index on slice
+//	re=^Q[1-4]$              slice or array field of columns matched on fieldNames, requires fieldNames
+//	null=NULL|N/A            null values of field instead of Params.NullTokens, first one is rendered by ToSlice
+//	decimal=comma            decimal separator of numbers instead of Params.NumberFormat, see NumberFormat
+//	group=space              grouping separator of numbers instead of Params.NumberFormat, see NumberFormat
+//	currency=$|USD           currency symbol before or after number is stripped
+//	currency_required        number without currency symbol is error, symbol is rendered by ToSlice
+//	currency_field=Currency  string field of same struct for found currency symbol, rendered on same side
+//	currency_pos=suffix      currency symbol is rendered after number, default before
+//	parens                   negative number in parentheses, like (150.00)
+//	percent                  15% is 0.15, percent sign is required, for float and Decimal fields
+//	scale=2                  Decimal digits after point, integer field is minor units, like 12.34 is 1234
+//	round=half_even          rounding of scale, see RoundingMode, without it extra digits are error
+//	conv=name                converter set by SetConverter(name, converter)
+//	true=a|b                 accepted true values of bool field
+//	false=a|b                accepted false values of bool field
+//
+// Embedded structs are flattened, fields of struct field without converter are mapped as
+// name.field, like address.city, struct pointers are allocated only if nested field is set.
+// Without fieldNames nested fields take slice items depth-first.
+//
+// Positional form ss:"name,omitempty,layout" and ss:"name,,layout" is supported.
+// Unknown or malformed option is ErrInvalidTag, returned by Err and ToStruct.
+package slicetostruct
@@ -0,0 +1,118 @@
+package slicetostruct
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+var (
+	ErrParse           = fmt.Errorf("cant parse value")
+	ErrUnsupportedType = fmt.Errorf("type not implement")
+	ErrMissingColumn   = fmt.Errorf("column does not exist")
+	ErrRequired        = fmt.Errorf("value is required")
+	ErrUnknownColumn   = fmt.Errorf("column is not mapped to field")
+)
+
+// FieldError describes field of row which could not be converted.
+type FieldError struct {
+	// struct field name
+	Field string
+	// name of field on slice, from ss tag or struct field name
+	Column     string
+	SliceIndex int
+	Value      string
+	Type       string
+	// one of ErrParse, ErrUnsupportedType, ErrMissingColumn, ErrRequired, ErrUnknownColumn, matched by errors.Is
+	Cause error
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field = %s, column = %s, index = %d, value = %s, type = %s: %v", e.Field, e.Column, e.SliceIndex, e.Value, e.Type, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+func (e *FieldError) Is(target error) bool {
+	return e.Cause != nil && e.Cause == target
+}
+
+func newFieldError(fp *fieldPlan, sliceIndex int, item string, cause error, err error) *FieldError {
+	return &FieldError{
+		Field:      fp.name,
+		Column:     fp.sliceName,
+		SliceIndex: sliceIndex,
+		Value:      item,
+		Type:       fp.fieldType,
+		Cause:      cause,
+		Err:        err,
+	}
+}
+
+// RowError is returned by ToStruct when Params.CollectErrors is set, it has all failed fields of row,
+// partially filled struct is returned with it.
+type RowError struct {
+	Errors []FieldError
+}
+
+func (e *RowError) Error() string {
+	res := make([]string, 0, len(e.Errors))
+	for i := range e.Errors {
+		res = append(res, e.Errors[i].Error())
+	}
+	return fmt.Sprintf("%d field errors: %s", len(e.Errors), strings.Join(res, "; "))
+}
+
+// Is reports whether any field error matches target.
+func (e *RowError) Is(target error) bool {
+	for i := range e.Errors {
+		if errors.Is(&e.Errors[i], target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *RowError) append(fieldErr *FieldError) *RowError {
+	if e == nil {
+		e = &RowError{}
+	}
+	e.Errors = append(e.Errors, *fieldErr)
+	return e
+}
+
+// HeaderError is returned by New, SetFieldNames and ToStruct when fieldNames do not match struct,
+// like missing required columns or unknown columns with StrictnessStrict, no row is converted then.
+type HeaderError struct {
+	Errors []FieldError
+}
+
+func (e *HeaderError) Error() string {
+	res := make([]string, 0, len(e.Errors))
+	for i := range e.Errors {
+		res = append(res, e.Errors[i].Error())
+	}
+	return fmt.Sprintf("%d header errors: %s", len(e.Errors), strings.Join(res, "; "))
+}
+
+// Is reports whether any field error matches target.
+func (e *HeaderError) Is(target error) bool {
+	for i := range e.Errors {
+		if errors.Is(&e.Errors[i], target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *HeaderError) append(fieldErr *FieldError) *HeaderError {
+	if e == nil {
+		e = &HeaderError{}
+	}
+	e.Errors = append(e.Errors, *fieldErr)
+	return e
+}
@@ -0,0 +1,56 @@
+package slicetostruct
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/go-faster/errors"
+)
+
+// ConvertFloat converts float32, float64, pointers to them and types with underlying float kind.
+type ConvertFloat struct {
+	params *Params
+}
+
+func (c *ConvertFloat) Set(value *ConvertValueParams) error {
+	fieldType := value.ReflectValue.Type()
+	if fieldType.Kind() == reflect.Ptr {
+		if value.Items[value.Index] == "" {
+			return nil
+		}
+		fieldType = fieldType.Elem()
+	}
+	v, err := parseFloat(value.fieldOptions(c.params), value.Items[value.Index], fieldType.Bits())
+	if err != nil {
+		return err
+	}
+	elem(value.ReflectValue).SetFloat(v)
+	return nil
+}
+
+func (c *ConvertFloat) Format(value *FormatValueParams) (string, error) {
+	v := *value.ReflectValue
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	return formatFloat(value.fieldOptions(c.params), v.Float(), v.Type().Bits()), nil
+}
+
+func parseFloat(o *fieldOptions, item string, bitSize int) (float64, error) {
+	item, err := normalizeNumber(o, item)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseFloat(item, bitSize)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cant ParseFloat, %s", item)
+	}
+	return v, nil
+}
+
+func formatFloat(o *fieldOptions, v float64, bitSize int) string {
+	return formatNumber(o, strconv.FormatFloat(v, 'f', -1, bitSize))
+}
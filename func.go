@@ -0,0 +1,83 @@
+package slicetostruct
+
+import (
+	"reflect"
+)
+
+// FieldContext describes field for functions registered by RegisterFunc and RegisterFormatFunc
+// and for Params.DefaultValue.
+type FieldContext struct {
+	// struct field name, empty for converter
+	Field string
+	// name of field on slice, from ss tag or struct field name
+	Column string
+	// index on slice, -1 for ToSlice and Params.DefaultValue
+	Index int
+	Tags  []string
+	Type  reflect.Type
+}
+
+type funcConverter[F any] struct {
+	fn func(raw string, ctx FieldContext) (F, error)
+}
+
+func (c *funcConverter[F]) Set(value *ConvertValueParams) error {
+	if value.ReflectValue.Kind() == reflect.Ptr && value.Items[value.Index] == "" {
+		return nil
+	}
+	v, err := c.fn(value.Items[value.Index], FieldContext{
+		Column: *value.FieldName,
+		Index:  value.Index,
+		Tags:   value.Tags,
+		Type:   value.ReflectValue.Type(),
+	})
+	if err != nil {
+		return err
+	}
+	elem(value.ReflectValue).Set(reflect.ValueOf(&v).Elem())
+	return nil
+}
+
+type funcFormatter[F any] struct {
+	fn func(v F, ctx FieldContext) (string, error)
+}
+
+func (c *funcFormatter[F]) Format(value *FormatValueParams) (string, error) {
+	v := *value.ReflectValue
+	if v.Kind() == reflect.Ptr && v.Type().Elem() == reflect.TypeOf((*F)(nil)).Elem() {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	return c.fn(v.Interface().(F), FieldContext{
+		Column: *value.FieldName,
+		Index:  -1,
+		Tags:   value.Tags,
+		Type:   value.ReflectValue.Type(),
+	})
+}
+
+// RegisterFunc sets fn as converter of F and *F fields, like
+//
+//	RegisterFunc(sTS, func(raw string, ctx FieldContext) (Money, error) {...})
+func RegisterFunc[T, F any](sTS *SliceToStruct[T], fn func(raw string, ctx FieldContext) (F, error)) {
+	fieldType := reflect.TypeOf((*F)(nil)).Elem()
+	converter := &funcConverter[F]{
+		fn: fn,
+	}
+	sTS.converters.SetTypeConverter(fieldType, converter)
+	sTS.converters.SetTypeConverter(reflect.PtrTo(fieldType), converter)
+	sTS.compile()
+}
+
+// RegisterFormatFunc sets fn as formatter of F and *F fields for ToSlice.
+func RegisterFormatFunc[T, F any](sTS *SliceToStruct[T], fn func(v F, ctx FieldContext) (string, error)) {
+	fieldType := reflect.TypeOf((*F)(nil)).Elem()
+	formatter := &funcFormatter[F]{
+		fn: fn,
+	}
+	sTS.converters.SetTypeFormatter(fieldType, formatter)
+	sTS.converters.SetTypeFormatter(reflect.PtrTo(fieldType), formatter)
+	sTS.compile()
+}
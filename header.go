@@ -0,0 +1,100 @@
+package slicetostruct
+
+import (
+	"strings"
+)
+
+type HeaderColumn struct {
+	// struct field name, empty for column without field
+	Field string
+	// name on header, lower cased if NotCaseSensitive
+	Column string
+	// index on header, -1 for field without column
+	Index    int
+	Required bool
+}
+
+// HeaderReport describes how header maps to struct, see ValidateHeader.
+type HeaderReport struct {
+	Mapped []HeaderColumn
+	// struct fields which header does not have
+	MissingFields []HeaderColumn
+	// header columns without struct field
+	UnknownColumns []HeaderColumn
+	// repeated header columns, SetFieldNames uses last one
+	Duplicates []HeaderColumn
+	// header columns which differ only by case, if NotCaseSensitive
+	CaseCollisions []HeaderColumn
+}
+
+// OK reports whether every field and every column are mapped once.
+func (r *HeaderReport) OK() bool {
+	return len(r.MissingFields) == 0 && len(r.UnknownColumns) == 0 && len(r.Duplicates) == 0 && len(r.CaseCollisions) == 0
+}
+
+// ValidateHeader returns report of header without SetFieldNames, error is error which SetFieldNames would return.
+func (sTS *SliceToStruct[T]) ValidateHeader(header []string) (*HeaderReport, error) {
+	tmp := *sTS
+	err := tmp.SetFieldNames(header)
+
+	names := make([]string, len(header))
+	for i := range header {
+		names[i] = header[i]
+		if sTS.NotCaseSensitive {
+			names[i] = strings.ToLower(names[i])
+		}
+	}
+
+	report := &HeaderReport{}
+	claimed := make(map[int]bool, len(header))
+	for i := range tmp.plan.fields {
+		fp := &tmp.plan.fields[i]
+		column := HeaderColumn{
+			Field:    fp.name,
+			Column:   fp.sliceName,
+			Index:    fp.sliceIndex,
+			Required: fp.required,
+		}
+		if fp.sliceIndex < 0 {
+			report.MissingFields = append(report.MissingFields, column)
+			continue
+		}
+		if fp.columns != nil {
+			for _, index := range fp.columns.columns {
+				claimed[index] = true
+				column.Column, column.Index = names[index], index
+				report.Mapped = append(report.Mapped, column)
+			}
+			continue
+		}
+		claimed[fp.sliceIndex] = true
+		report.Mapped = append(report.Mapped, column)
+	}
+
+	for i := range header {
+		if !claimed[i] && tmp.fieldNames[names[i]] == i {
+			report.UnknownColumns = append(report.UnknownColumns, HeaderColumn{
+				Column: header[i],
+				Index:  i,
+			})
+		}
+		duplicate, collision := false, false
+		for j := 0; j < i; j++ {
+			duplicate = duplicate || header[j] == header[i]
+			collision = collision || names[j] == names[i]
+		}
+		switch {
+		case duplicate:
+			report.Duplicates = append(report.Duplicates, HeaderColumn{
+				Column: header[i],
+				Index:  i,
+			})
+		case collision:
+			report.CaseCollisions = append(report.CaseCollisions, HeaderColumn{
+				Column: header[i],
+				Index:  i,
+			})
+		}
+	}
+	return report, err
+}
@@ -20,3 +20,7 @@ func (c *ConvertInt) Set(value *ConvertValueParams) error {
 	value.ReflectValue.Set(reflect.ValueOf(c.Value))
 	return nil
 }
+
+func (c *ConvertInt) Format(value *FormatValueParams) (string, error) {
+	return strconv.FormatInt(value.ReflectValue.Int(), 10), nil
+}
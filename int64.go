@@ -19,3 +19,7 @@ func (c *ConvertInt64) Set(value *ConvertValueParams) error {
 	value.ReflectValue.SetInt(c.Value)
 	return nil
 }
+
+func (c *ConvertInt64) Format(value *FormatValueParams) (string, error) {
+	return strconv.FormatInt(value.ReflectValue.Int(), 10), nil
+}
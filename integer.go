@@ -0,0 +1,129 @@
+package slicetostruct
+
+import (
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// ConvertInteger converts every signed and unsigned integer type and pointers to them,
+// value is parsed with bit size of field type, so overflow is error.
+type ConvertInteger struct {
+	params *Params
+}
+
+func (c *ConvertInteger) Set(value *ConvertValueParams) error {
+	item := value.Items[value.Index]
+	fieldType := value.ReflectValue.Type()
+	if fieldType.Kind() == reflect.Ptr {
+		if item == "" {
+			return nil
+		}
+		fieldType = fieldType.Elem()
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := parseInt(value.fieldOptions(c.params), item, fieldType.Bits())
+		if err != nil {
+			return integerError(err, item, fieldType)
+		}
+		elem(value.ReflectValue).SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := parseUint(value.fieldOptions(c.params), item, fieldType.Bits())
+		if err != nil {
+			return integerError(err, item, fieldType)
+		}
+		elem(value.ReflectValue).SetUint(v)
+	default:
+		return errors.Errorf("type is not integer, %s", value.FieltType)
+	}
+	return nil
+}
+
+func (c *ConvertInteger) Format(value *FormatValueParams) (string, error) {
+	v := *value.ReflectValue
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	o := value.fieldOptions(c.params)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if o.hasScale {
+			return formatScaled(o, big.NewInt(v.Int())), nil
+		}
+		return formatNumber(o, strconv.FormatInt(v.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if o.hasScale {
+			return formatScaled(o, new(big.Int).SetUint64(v.Uint())), nil
+		}
+		return formatNumber(o, strconv.FormatUint(v.Uint(), 10)), nil
+	default:
+		return "", errors.Errorf("type is not integer, %s", value.FieltType)
+	}
+}
+
+func integerError(err error, item string, fieldType reflect.Type) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return errors.Wrapf(err, "value %s out of range for %s", item, fieldType)
+	}
+	return errors.Wrapf(err, "cant parse %s", fieldType)
+}
+
+func parseInt(o *fieldOptions, item string, bitSize int) (int64, error) {
+	item, err := normalizeInteger(o, item)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(item, intBase(o, item), bitSize)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cant ParseInt, %s", item)
+	}
+	return v, nil
+}
+
+func parseUint(o *fieldOptions, item string, bitSize int) (uint64, error) {
+	item, err := normalizeInteger(o, item)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(item, intBase(o, item), bitSize)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cant ParseUint, %s", item)
+	}
+	return v, nil
+}
+
+// normalizeInteger converts integer of field format to strconv format,
+// with scale tag option value is integer of minor units, like 12.34 with scale=2 is 1234.
+func normalizeInteger(o *fieldOptions, item string) (string, error) {
+	if !o.hasScale {
+		return normalizeNumber(o, item)
+	}
+	coef, err := parseScaled(o, item)
+	if err != nil {
+		return "", err
+	}
+	return coef.String(), nil
+}
+
+// intBase returns 0 (base is taken from 0x, 0o, 0b prefix, underscores are allowed)
+// if Params.AllowIntBasePrefix is set, except numbers with leading zero like 007,
+// which are decimal, not octal.
+func intBase(o *fieldOptions, item string) int {
+	if o.params == nil || !o.params.AllowIntBasePrefix {
+		return 10
+	}
+	s := strings.TrimLeft(item, "+-")
+	if len(s) > 1 && s[0] == '0' && !strings.ContainsRune("xXoObB", rune(s[1])) {
+		return 10
+	}
+	return 0
+}
@@ -0,0 +1,217 @@
+package slicetostruct
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-faster/errors"
+)
+
+var sqlNullFloat64Type = reflect.TypeOf(sql.NullFloat64{})
+
+// money options of number fields, like $1,234.50, (150.00) and 15%:
+// currency=$|USD strips currency symbol before or after number, currency_required requires it,
+// currency_field=Currency sets found symbol to string field of same struct, ToSlice renders it
+// on side where it was found,
+// currency_pos=suffix renders symbol after number, default before,
+// parens is negative number in parentheses, percent converts 15% to 0.15 for float and Decimal fields,
+// percent sign is required.
+func hasMoneyOptions(tags []string) bool {
+	for i := 3; i < len(tags); i++ {
+		switch {
+		case tags[i] == "parens", tags[i] == "percent", strings.HasPrefix(tags[i], "currency="):
+			return true
+		}
+	}
+	return false
+}
+
+// tagFlag reports whether tag has flag.
+func tagFlag(tags []string, flag string) bool {
+	for i := 1; i < len(tags); i++ {
+		if tags[i] == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// stripMoney removes parentheses, currency symbol and percent sign of item.
+func stripMoney(tags []string, item string) (string, bool, bool, error) {
+	item = strings.TrimSpace(item)
+	parens := tagFlag(tags, "parens")
+	negative := false
+	if parens && strings.HasPrefix(item, "(") && strings.HasSuffix(item, ")") {
+		negative = true
+		item = strings.TrimSpace(item[1 : len(item)-1])
+	}
+	if symbols, ok := tagOption(tags, "currency"); ok {
+		var symbol string
+		symbol, item, _ = cutCurrency(item, symbols)
+		if symbol == "" && tagFlag(tags, "currency_required") {
+			return "", false, false, errors.Errorf("currency %s is required", symbols)
+		}
+	}
+	if parens && !negative && strings.HasPrefix(item, "(") && strings.HasSuffix(item, ")") {
+		negative = true
+		item = strings.TrimSpace(item[1 : len(item)-1])
+	}
+	percent := tagFlag(tags, "percent")
+	if percent {
+		// ToSlice renders percent sign, so value without it would not be same after round trip
+		if !strings.HasSuffix(item, "%") {
+			return "", false, false, errors.Errorf("number without percent sign %s", item)
+		}
+		item = strings.TrimSpace(strings.TrimSuffix(item, "%"))
+	}
+	if negative && (strings.HasPrefix(item, "-") || strings.HasPrefix(item, "+")) {
+		return "", false, false, errors.Errorf("number in parentheses has sign %s", item)
+	}
+	return item, negative, percent, nil
+}
+
+// cutCurrency returns currency symbol found before or after number, number without it
+// and whether symbol is after number.
+func cutCurrency(item string, symbols string) (string, string, bool) {
+	sign := ""
+	if strings.HasPrefix(item, "-") || strings.HasPrefix(item, "+") {
+		sign = item[:1]
+	}
+	for _, symbol := range strings.Split(symbols, "|") {
+		if symbol == "" {
+			continue
+		}
+		if strings.HasPrefix(item[len(sign):], symbol) {
+			return symbol, sign + strings.TrimSpace(item[len(sign)+len(symbol):]), false
+		}
+		if strings.HasSuffix(item, symbol) {
+			return symbol, strings.TrimSpace(item[:len(item)-len(symbol)]), true
+		}
+	}
+	return "", item, false
+}
+
+// currencySymbol returns currency symbol of item and whether it is after number, for currency_field.
+func currencySymbol(tags []string, item string) (string, bool) {
+	symbols, ok := tagOption(tags, "currency")
+	if !ok {
+		return "", false
+	}
+	item = strings.TrimSpace(item)
+	if tagFlag(tags, "parens") {
+		item = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(item, "("), ")"))
+	}
+	symbol, _, suffix := cutCurrency(item, symbols)
+	return symbol, suffix
+}
+
+// formatMoney adds currency symbol and parentheses or sign to formatted absolute number,
+// currency symbol is rendered if currency_required is set.
+func formatMoney(tags []string, item string, negative bool) string {
+	if tagFlag(tags, "currency_required") {
+		symbols, _ := tagOption(tags, "currency")
+		symbol, _, _ := strings.Cut(symbols, "|")
+		if pos, _ := tagOption(tags, "currency_pos"); pos == "suffix" {
+			item = item + " " + symbol
+		} else {
+			item = symbol + item
+		}
+	}
+	switch {
+	case negative && tagFlag(tags, "parens"):
+		return "(" + item + ")"
+	case negative:
+		return "-" + item
+	}
+	return item
+}
+
+// currencyTags returns copy of tags, which render currency symbol of currency_field,
+// on side where ToStruct found it, or by currency_pos if symbol was not parsed.
+func currencyTags(fp *fieldPlan, symbol string) []string {
+	res := make([]string, 0, len(fp.tags)+2)
+	for _, tag := range fp.tags {
+		if !strings.HasPrefix(tag, "currency=") && tag != "currency_required" {
+			res = append(res, tag)
+		}
+	}
+	res = append(res, "currency="+symbol, "currency_required")
+	if suffix, ok := fp.currencySuffix.Load(symbol); ok {
+		pos := "prefix"
+		if suffix.(bool) {
+			pos = "suffix"
+		}
+		res = removeTagOption(res, "currency_pos")
+		res = append(res, "currency_pos="+pos)
+	}
+	return res
+}
+
+// removeTagOption returns tags without key=value option.
+func removeTagOption(tags []string, key string) []string {
+	res := tags[:0]
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, key+"=") {
+			res = append(res, tag)
+		}
+	}
+	return res
+}
+
+// shiftDecimal moves decimal point of number of strconv format, like 15 to 0.15 by shift -2.
+func shiftDecimal(item string, shift int) string {
+	sign := ""
+	if strings.HasPrefix(item, "-") || strings.HasPrefix(item, "+") {
+		sign, item = item[:1], item[1:]
+	}
+	intPart, frac, _ := strings.Cut(item, ".")
+	digits := intPart + frac
+	point := len(intPart) + shift
+	for point <= 0 {
+		digits = "0" + digits
+		point++
+	}
+	for point > len(digits) {
+		digits += "0"
+	}
+	intPart, frac = strings.TrimLeft(digits[:point], "0"), strings.TrimRight(digits[point:], "0")
+	if intPart == "" {
+		intPart = "0"
+	}
+	if frac == "" {
+		return sign + intPart
+	}
+	return sign + intPart + "." + frac
+}
+
+// compileMoney validates money options of field and resolves currency_field of struct.
+func compileMoney(fp *fieldPlan, fieldType reflect.Type, structType reflect.Type, parentPath []int, tag tagOptions) error {
+	_, hasCurrency := tag.option("currency")
+	currencyField, hasCurrencyField := tag.option("currency_field")
+	if !hasCurrency && (hasCurrencyField || tag.flags["currency_required"]) {
+		return errors.Wrapf(ErrInvalidTag, "field = %s, currency_field and currency_required require currency", fp.name)
+	}
+	if pos, ok := tag.option("currency_pos"); ok && pos != "prefix" && pos != "suffix" {
+		return errors.Wrapf(ErrInvalidTag, "field = %s, currency_pos should be prefix or suffix", fp.name)
+	}
+	if tag.flags["percent"] {
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() != reflect.Float32 && fieldType.Kind() != reflect.Float64 && fieldType != sqlNullFloat64Type && fieldType != decimalType {
+			return errors.Wrapf(ErrInvalidTag, "field = %s, percent requires float or Decimal field", fp.name)
+		}
+	}
+	if !hasCurrencyField {
+		return nil
+	}
+	sibling, ok := structType.FieldByName(currencyField)
+	if !ok || sibling.Type.Kind() != reflect.String || !sibling.IsExported() {
+		return errors.Wrapf(ErrInvalidTag, "field = %s, currency_field %s should be exported string field of struct", fp.name, currencyField)
+	}
+	fp.currencyPath = append(parentPath[:len(parentPath):len(parentPath)], sibling.Index...)
+	fp.currencySuffix = &sync.Map{}
+	return nil
+}
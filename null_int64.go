@@ -23,3 +23,10 @@ func (c *ConvertNullInt64) Set(value *ConvertValueParams) error {
 	value.ReflectValue.Set(reflect.ValueOf(c.Value))
 	return nil
 }
+
+func (c *ConvertNullInt64) Format(value *FormatValueParams) (string, error) {
+	if value.ReflectValue.IsNil() {
+		return "", nil
+	}
+	return strconv.FormatInt(value.ReflectValue.Elem().Int(), 10), nil
+}
@@ -0,0 +1,200 @@
+package slicetostruct
+
+import (
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+// NumberFormat is decimal and grouping separators of integer and float fields, like 1 234 567,89,
+// separator is string or name: comma, dot, space, nbsp, thin, apostrophe, none.
+// Field can override it by tag options decimal= and group=.
+type NumberFormat struct {
+	// default dot, or comma if Params.ReplaceCommaToDot is set
+	Decimal string
+	// separator of thousands, default none, grouped number should have groups of 3 digits
+	Group string
+}
+
+// separators by name, space accepts no-break spaces, which are usual in exported sheets,
+// first separator is used by ToSlice
+var numberSeparators = map[string][]string{
+	"comma":      {","},
+	"dot":        {"."},
+	"space":      {" ", "\u00a0", "\u202f"},
+	"nbsp":       {"\u00a0", "\u202f"},
+	"thin":       {"\u2009", "\u202f"},
+	"apostrophe": {"'", "\u2019"},
+	"none":       nil,
+}
+
+func numberSeparator(name string) []string {
+	if v, ok := numberSeparators[name]; ok {
+		return v
+	}
+	if name == "" {
+		return nil
+	}
+	return []string{name}
+}
+
+// numberFormat returns decimal separator and accepted grouping separators of field,
+// from tag options or Params.
+func (o *fieldOptions) numberFormat() (string, []string) {
+	decimal, group := ".", ""
+	if o.params != nil {
+		if o.params.ReplaceCommaToDot {
+			decimal = ","
+		}
+		if o.params.NumberFormat.Decimal != "" {
+			decimal = o.params.NumberFormat.Decimal
+		}
+		group = o.params.NumberFormat.Group
+	}
+	if o.decimal != "" {
+		decimal = o.decimal
+	}
+	if o.hasGroup {
+		group = o.group
+	}
+	decimals := numberSeparator(decimal)
+	if len(decimals) > 0 {
+		decimal = decimals[0]
+	}
+	return decimal, numberSeparator(group)
+}
+
+// validateNumberFormat checks that decimal and grouping separators are different.
+func validateNumberFormat(o *fieldOptions) error {
+	decimal, groups := o.numberFormat()
+	for _, group := range groups {
+		if group == decimal {
+			return errors.Wrapf(ErrInvalidTag, "decimal and group separators are same %q", decimal)
+		}
+	}
+	return nil
+}
+
+// normalizeNumber converts number of field format to strconv format, like 1 234,5 to 1234.5.
+func normalizeNumber(o *fieldOptions, item string) (string, error) {
+	decimal, groups := o.numberFormat()
+	money := o.money
+	if decimal == "." && len(groups) == 0 && !money {
+		return item, nil
+	}
+
+	orig := item
+	negative, percent := false, false
+	if money {
+		stripped, n, p, err := stripMoney(o.tags, item)
+		if err != nil {
+			return "", errors.Wrapf(err, "cant parse number %q", item)
+		}
+		item, negative, percent = stripped, n, p
+	}
+	sign := ""
+	if strings.HasPrefix(item, "-") || strings.HasPrefix(item, "+") {
+		sign, item = item[:1], item[1:]
+	}
+	intPart, frac, hasFrac := strings.Cut(item, decimal)
+	if strings.Contains(frac, decimal) {
+		return "", errors.Errorf("cant parse number %q, decimal separator %q is repeated", sign+item, decimal)
+	}
+	if len(groups) > 0 {
+		var err error
+		intPart, err = ungroup(intPart, groups)
+		if err != nil {
+			return "", errors.Wrapf(err, "cant parse number %q", sign+item)
+		}
+	}
+	if negative {
+		sign = "-"
+	}
+	res := sign + intPart
+	if hasFrac {
+		res += "." + frac
+	}
+	if percent {
+		// shiftDecimal moves point of plain digits only, exponent like 1e3% is error
+		if !isDigits(intPart + frac) {
+			return "", errors.Errorf("cant parse percent %q", orig)
+		}
+		res = shiftDecimal(res, -2)
+	}
+	return res, nil
+}
+
+// isDigits reports whether item is not empty and has only ascii digits.
+func isDigits(item string) bool {
+	for i := 0; i < len(item); i++ {
+		if item[i] < '0' || item[i] > '9' {
+			return false
+		}
+	}
+	return item != ""
+}
+
+// ungroup removes grouping separators, first group has 1 to 3 digits, next groups have 3 digits.
+func ungroup(item string, groups []string) (string, error) {
+	const marker = "\x00"
+	grouped := item
+	for _, group := range groups {
+		grouped = strings.ReplaceAll(grouped, group, marker)
+	}
+	parts := strings.Split(grouped, marker)
+	if len(parts) == 1 {
+		return item, nil
+	}
+	if len(parts[0]) < 1 || len(parts[0]) > 3 {
+		return "", errors.Errorf("invalid grouping, first group %q", parts[0])
+	}
+	for _, part := range parts[1:] {
+		if len(part) != 3 {
+			return "", errors.Errorf("invalid grouping, group %q", part)
+		}
+	}
+	return strings.Join(parts, ""), nil
+}
+
+// formatNumber converts number of strconv format to field format, like 1234.5 to 1 234,5.
+func formatNumber(o *fieldOptions, item string) string {
+	decimal, groups := o.numberFormat()
+	money := o.money
+	if decimal == "." && len(groups) == 0 && !money {
+		return item
+	}
+
+	percent := money && tagFlag(o.tags, "percent")
+	if percent {
+		item = shiftDecimal(item, 2)
+	}
+	negative := strings.HasPrefix(item, "-")
+	if negative {
+		item = item[1:]
+	}
+	intPart, frac, hasFrac := strings.Cut(item, ".")
+	if len(groups) > 0 && len(intPart) > 3 {
+		var b strings.Builder
+		for i := range intPart {
+			if i > 0 && (len(intPart)-i)%3 == 0 {
+				b.WriteString(groups[0])
+			}
+			b.WriteByte(intPart[i])
+		}
+		intPart = b.String()
+	}
+	res := intPart
+	if hasFrac {
+		res += decimal + frac
+	}
+	if percent {
+		res += "%"
+	}
+	if money {
+		return formatMoney(o.tags, res, negative)
+	}
+	if negative {
+		return "-" + res
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+package slicetostruct
+
+import (
+	"strings"
+	"time"
+)
+
+// fieldOptions are options of field from ss tag, they are parsed once by compile,
+// so converters do not parse tag for every row. Params are read on every call,
+// so they can be changed after New like ReplaceCommaToDot.
+type fieldOptions struct {
+	params *Params
+	tags   []string
+	// layouts of tag, nil without layout
+	timeLayouts []string
+	// location of tz option, nil without it
+	location    *time.Location
+	locationErr error
+	// tokens of true= and false= options, nil without them
+	trueTokens  []string
+	falseTokens []string
+	// decimal= and group= options
+	decimal  string
+	group    string
+	hasGroup bool
+	// tag has currency, parens or percent option
+	money    bool
+	scale    int
+	hasScale bool
+	round    RoundingMode
+}
+
+func newFieldOptions(params *Params, tags []string) *fieldOptions {
+	o := &fieldOptions{
+		params: params,
+		tags:   tags,
+		money:  hasMoneyOptions(tags),
+		round:  roundingMode(tags),
+	}
+	// layouts of tag are separated by |, like layout=02.01.2006|2006-01-02
+	if len(tags) > 2 && tags[2] != "" {
+		o.timeLayouts = strings.Split(tags[2], "|")
+	}
+	if tz, ok := tagOption(tags, "tz"); ok {
+		o.location, o.locationErr = loadLocation(tz)
+	}
+	if v, ok := tagOption(tags, "true"); ok {
+		o.trueTokens = strings.Split(v, "|")
+	}
+	if v, ok := tagOption(tags, "false"); ok {
+		o.falseTokens = strings.Split(v, "|")
+	}
+	o.decimal, _ = tagOption(tags, "decimal")
+	o.group, o.hasGroup = tagOption(tags, "group")
+	o.scale, o.hasScale = decimalScale(tags)
+	return o
+}
+
+// fieldOptions returns options parsed by compile, or parses them for converter called directly.
+func (value *ConvertValueParams) fieldOptions(params *Params) *fieldOptions {
+	if value.options != nil {
+		return value.options
+	}
+	return newFieldOptions(params, value.Tags)
+}
+
+func (value *FormatValueParams) fieldOptions(params *Params) *fieldOptions {
+	if value.options != nil {
+		return value.options
+	}
+	return newFieldOptions(params, value.Tags)
+}
@@ -0,0 +1,380 @@
+package slicetostruct
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-faster/errors"
+)
+
+// fieldPlan is everything ToStruct and ToSlice need to know about struct field,
+// it is compiled once per SetFieldNames/SetConverter call instead of every row.
+type fieldPlan struct {
+	// index of field on slice if fieldNames are empty, nested fields are numbered depth-first
+	position int
+	// index sequence for nested fields
+	path      []int
+	name      string
+	sliceName string
+	// index on fieldNames, -1 if fieldNames are empty or does not have sliceName
+	sliceIndex int
+	fieldType  string
+	tags       []string
+	pointer    bool
+	// field of nested struct pointer, empty value does not allocate struct
+	nestedPointer bool
+	omitEmpty     bool
+	required      bool
+	settable      bool
+	converter     Converter
+	formatter     Formatter
+	// tag options resolved for converters
+	options *fieldOptions
+	// sql.Scanner, like sql.NullInt64, null value is converted as empty string
+	nullable bool
+	// values which are null like empty string, see Params.NullTokens
+	nullTokens []string
+	nullOutput string
+	// string field of struct for currency symbol, see currency_field tag option
+	currencyPath []int
+	// side of currency symbols found by ToStruct, symbol to true if it is after number
+	currencySuffix *sync.Map
+	// columns of field with range or re tag, nil for field of single column
+	columns *columnsPlan
+	// default value from tag or Params.DefaultValue as items for converter, nil if field has not default
+	defaultItems []string
+}
+
+type plan struct {
+	fields []fieldPlan
+	// count of positions, see fieldPlan.position
+	numFields int
+	rest      *restPlan
+	// error of struct definition, like unknown converter in tag, returned by ToStruct and ToSlice
+	err error
+}
+
+// nestedStruct is struct field which fields are mapped to slice.
+type nestedStruct struct {
+	path []int
+	// go name of struct field with dot
+	name string
+	// prefix of names of nested fields
+	prefix string
+	// path has pointer to struct
+	pointer bool
+	// nested struct types, to prevent recursion
+	types map[reflect.Type]bool
+}
+
+func (sTS *SliceToStruct[T]) compile() {
+	structType := reflect.TypeOf((*T)(nil)).Elem()
+	if structType.Kind() != reflect.Struct {
+		sTS.plan = &plan{
+			err: errors.New("generic type does not struct"),
+		}
+		return
+	}
+
+	p := &plan{}
+	var missingRequired *HeaderError
+	p.err = sTS.compileStruct(p, structType, nestedStruct{
+		types: map[reflect.Type]bool{structType: true},
+	}, &missingRequired)
+	if p.err == nil && missingRequired != nil {
+		p.err = missingRequired
+	}
+	if p.err == nil {
+		p.err = sTS.compileUnknownColumns(p)
+	}
+	sTS.plan = p
+}
+
+// compileStruct adds fields of structType to plan, embedded structs and struct fields
+// without converter are nested, like encoding/json.
+func (sTS *SliceToStruct[T]) compileStruct(p *plan, structType reflect.Type, parent nestedStruct, missingRequired **HeaderError) error {
+	for i := 0; i < structType.NumField(); i++ {
+		fieldInfo := structType.Field(i)
+		path := append(parent.path[:len(parent.path):len(parent.path)], i)
+		name := parent.name + fieldInfo.Name
+		tag, err := parseTag(fieldInfo.Tag.Get(keyTag))
+		if err != nil {
+			return errors.Wrapf(err, "field = %s", name)
+		}
+		sliceFieldName := fieldInfo.Name
+		if tag.name != "" {
+			sliceFieldName = tag.name
+		}
+		if sliceFieldName == "-" {
+			p.numFields++
+			continue
+		}
+		if tag.flags["rest"] {
+			if p.rest != nil || (fieldInfo.Type != restMapType && fieldInfo.Type != restCellsType) {
+				return errors.Wrapf(ErrInvalidTag, "field = %s, rest should be single map[string]string or []Cell field", name)
+			}
+			p.rest = &restPlan{
+				path:  path,
+				cells: fieldInfo.Type == restCellsType,
+			}
+			// rest field does not take position, items after last field are collected
+			continue
+		}
+
+		converter, formatter := sTS.converters.resolve(fieldInfo.Type)
+		if convName, ok := tag.option("conv"); ok {
+			converter, err = sTS.converters.GetConverter(convName)
+			if err != nil {
+				return errors.Wrapf(err, "cant sTS.converters.GetConverter, field = %s, conv = %s", name, convName)
+			}
+			if f, ok := converter.(Formatter); ok {
+				formatter = f
+			}
+		}
+
+		if nested, ok := sTS.nested(fieldInfo, tag, converter, parent, path, sliceFieldName); ok {
+			nestedType := fieldInfo.Type
+			if nestedType.Kind() == reflect.Ptr {
+				nestedType = nestedType.Elem()
+			}
+			if parent.types[nestedType] {
+				return errors.Wrapf(ErrInvalidTag, "field = %s, recursive struct %s", name, nestedType)
+			}
+			nested.types[nestedType] = true
+			err = sTS.compileStruct(p, nestedType, nested, missingRequired)
+			delete(nested.types, nestedType)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		sliceFieldName = parent.prefix + sliceFieldName
+		if sTS.NotCaseSensitive {
+			sliceFieldName = strings.ToLower(sliceFieldName)
+		}
+		fp := fieldPlan{
+			position:      p.numFields,
+			path:          path,
+			name:          name,
+			sliceName:     sliceFieldName,
+			sliceIndex:    -1,
+			fieldType:     fieldInfo.Type.String(),
+			tags:          tag.tags,
+			pointer:       fieldInfo.Type.Kind() == reflect.Ptr,
+			nestedPointer: parent.pointer,
+			omitEmpty:     tag.omitEmpty,
+			required:      tag.flags["required"],
+			settable:      fieldInfo.IsExported(),
+			converter:     converter,
+			formatter:     formatter,
+		}
+		fp.options = newFieldOptions(&sTS.Params, tag.tags)
+		if fp.options.locationErr != nil {
+			return errors.Wrapf(fp.options.locationErr, "field = %s", name)
+		}
+		err = validateNumberFormat(fp.options)
+		if err != nil {
+			return errors.Wrapf(err, "field = %s", name)
+		}
+		fp.nullTokens, fp.nullOutput = sTS.nullTokens(tag)
+		fp.nullable = fieldInfo.Type.Kind() != reflect.Ptr && implements(fieldInfo.Type, scannerType)
+		_, hasRange := tag.option("range")
+		if _, hasRe := tag.option("re"); hasRange || hasRe {
+			err = sTS.compileColumns(p, &fp, fieldInfo.Type, tag)
+			if err != nil {
+				return err
+			}
+			if fp.required && len(sTS.fieldNames) > 0 && fp.sliceIndex < 0 {
+				*missingRequired = (*missingRequired).append(newFieldError(&fp, -1, "", ErrRequired, errors.Errorf("required columns %s do not exist on fieldNames", sliceFieldName)))
+			}
+			p.fields = append(p.fields, fp)
+			continue
+		}
+		p.numFields++
+		if v, ok := sTS.fieldNames[sliceFieldName]; ok {
+			fp.sliceIndex = v
+		}
+		if fp.required && len(sTS.fieldNames) > 0 && fp.sliceIndex < 0 {
+			*missingRequired = (*missingRequired).append(newFieldError(&fp, -1, "", ErrRequired, errors.Errorf("required column %s does not exist on fieldNames", sliceFieldName)))
+		}
+		err = compileMoney(&fp, fieldInfo.Type, structType, parent.path, tag)
+		if err != nil {
+			return err
+		}
+		err = compileScale(&fp, fieldInfo.Type, tag)
+		if err != nil {
+			return err
+		}
+		err = sTS.compileDefault(&fp, fieldInfo.Type, tag)
+		if err != nil {
+			return err
+		}
+		p.fields = append(p.fields, fp)
+	}
+	return nil
+}
+
+// nested returns nestedStruct if fields of struct field should be mapped instead of field itself:
+// embedded struct without name in tag and struct without converter are nested,
+// struct with inline or prefix tag option is nested always.
+func (sTS *SliceToStruct[T]) nested(fieldInfo reflect.StructField, tag tagOptions, converter Converter, parent nestedStruct, path []int, sliceFieldName string) (nestedStruct, bool) {
+	fieldType := fieldInfo.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Struct {
+		return nestedStruct{}, false
+	}
+	// fields of unexported embedded struct can be set, but pointer can not be allocated
+	if !fieldInfo.IsExported() && !(fieldInfo.Anonymous && fieldInfo.Type.Kind() == reflect.Struct) {
+		return nestedStruct{}, false
+	}
+	prefix, hasPrefix := tag.option("prefix")
+	inline := tag.flags["inline"] || hasPrefix || (fieldInfo.Anonymous && tag.name == "")
+	if converter != nil && !inline {
+		return nestedStruct{}, false
+	}
+
+	res := nestedStruct{
+		path:    path,
+		name:    parent.name + fieldInfo.Name + ".",
+		prefix:  parent.prefix + prefix,
+		pointer: parent.pointer || fieldInfo.Type.Kind() == reflect.Ptr,
+		types:   parent.types,
+	}
+	if !inline {
+		res.prefix = parent.prefix + sliceFieldName + "."
+	}
+	return res, true
+}
+
+func (sTS *SliceToStruct[T]) compileDefault(fp *fieldPlan, fieldType reflect.Type, tag tagOptions) error {
+	defaultValue, ok := tag.option("default")
+	if !ok && sTS.DefaultValue != nil {
+		defaultValue, ok = sTS.DefaultValue(FieldContext{
+			Field:  fp.name,
+			Column: fp.sliceName,
+			Index:  -1,
+			Tags:   fp.tags,
+			Type:   fieldType,
+		})
+	}
+	if !ok {
+		return nil
+	}
+	if fp.required {
+		return errors.Wrapf(ErrInvalidTag, "field = %s, required field can not have default", fp.name)
+	}
+	if fp.converter == nil {
+		return errors.Wrapf(ErrUnsupportedType, "field = %s, type = %s", fp.name, fp.fieldType)
+	}
+
+	fp.defaultItems = []string{defaultValue}
+	field := reflect.New(fieldType).Elem()
+	params := ConvertValueParams{
+		ReflectValue: &field,
+	}
+	err := fp.set(&params, fp.defaultItems, 0)
+	if err != nil {
+		return errors.Wrapf(err, "invalid default value, field = %s, default = %s", fp.name, defaultValue)
+	}
+	return nil
+}
+
+// nullTokens returns null tokens of field and null value of ToSlice, null tag option overrides Params.NullTokens.
+func (sTS *SliceToStruct[T]) nullTokens(tag tagOptions) ([]string, string) {
+	if v, ok := tag.option("null"); ok {
+		tokens := strings.Split(v, "|")
+		return tokens, tokens[0]
+	}
+	return sTS.NullTokens, sTS.NullOutput
+}
+
+// isNull reports whether item is empty string or one of null tokens, tokens are case insensitive.
+func (fp *fieldPlan) isNull(item string) bool {
+	if item == "" {
+		return true
+	}
+	item = strings.TrimSpace(item)
+	for _, token := range fp.nullTokens {
+		if token != "" && strings.EqualFold(item, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNullValue reports whether field is nil pointer or driver.Valuer with nil value, like invalid sql.NullInt64.
+func isNullValue(field reflect.Value) bool {
+	if field.Kind() == reflect.Ptr {
+		return field.IsNil()
+	}
+	if !implements(field.Type(), valuerType) {
+		return false
+	}
+	v, _ := indirectInterface(field, valuerType)
+	value, err := v.Interface().(driver.Valuer).Value()
+	return err == nil && value == nil
+}
+
+// set converts items[index] to field, params.ReflectValue should point to field.
+func (fp *fieldPlan) set(params *ConvertValueParams, items []string, index int) error {
+	params.Items = items
+	params.Index = index
+	params.Tags = fp.tags
+	params.FieldName = &fp.sliceName
+	params.FieltType = fp.fieldType
+	params.options = fp.options
+	return fp.converter.Set(params)
+}
+
+// compileUnknownColumns finds columns of fieldNames without field, they are error for StrictnessStrict
+// or columns of rest field.
+func (sTS *SliceToStruct[T]) compileUnknownColumns(p *plan) error {
+	if sTS.Strictness == StrictnessCollect && p.rest == nil {
+		return errors.Wrap(ErrInvalidTag, "StrictnessCollect requires field with rest tag")
+	}
+	if p.rest != nil {
+		p.rest.extraFrom = p.numFields
+	}
+	if len(sTS.header) == 0 {
+		return nil
+	}
+
+	claimed := make([]bool, len(sTS.header))
+	for i := range p.fields {
+		if p.fields[i].sliceIndex >= 0 {
+			claimed[p.fields[i].sliceIndex] = true
+		}
+		if p.fields[i].columns != nil {
+			for _, index := range p.fields[i].columns.columns {
+				claimed[index] = true
+			}
+		}
+	}
+	var unknown *HeaderError
+	var columns []int
+	for i := range claimed {
+		if claimed[i] {
+			continue
+		}
+		columns = append(columns, i)
+		unknown = unknown.append(&FieldError{
+			Column:     sTS.header[i],
+			SliceIndex: i,
+			Cause:      ErrUnknownColumn,
+			Err:        errors.Errorf("column %s is not mapped to field", sTS.header[i]),
+		})
+	}
+	if sTS.Strictness == StrictnessStrict && unknown != nil {
+		return unknown
+	}
+	if p.rest != nil {
+		p.rest.columns = columns
+		p.rest.extraFrom = len(sTS.header)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package slicetostruct
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Cell is item of slice which is not mapped to field, see rest tag.
+type Cell struct {
+	Index int
+	// name on fieldNames as is, empty for item out of fieldNames
+	Column string
+	Value  string
+}
+
+var (
+	restMapType   = reflect.TypeOf(map[string]string(nil))
+	restCellsType = reflect.TypeOf([]Cell(nil))
+)
+
+// restPlan is field with rest tag, it collects items which are not mapped to fields,
+// map[string]string is keyed by name on fieldNames or by index for items out of fieldNames.
+type restPlan struct {
+	path  []int
+	cells bool
+	// indexes of fieldNames without field
+	columns []int
+	// items from extraFrom are not on fieldNames
+	extraFrom int
+}
+
+func (r *restPlan) set(curStruct reflect.Value, header []string, items []string) {
+	var cells []Cell
+	for _, i := range r.columns {
+		if i >= len(items) {
+			break
+		}
+		cells = append(cells, Cell{
+			Index:  i,
+			Column: header[i],
+			Value:  items[i],
+		})
+	}
+	for i := r.extraFrom; i < len(items); i++ {
+		cells = append(cells, Cell{
+			Index: i,
+			Value: items[i],
+		})
+	}
+	if cells == nil {
+		return
+	}
+
+	if r.cells {
+		fieldByIndex(curStruct, r.path).Set(reflect.ValueOf(cells))
+		return
+	}
+	rest := make(map[string]string, len(cells))
+	for _, cell := range cells {
+		rest[cell.key()] = cell.Value
+	}
+	fieldByIndex(curStruct, r.path).Set(reflect.ValueOf(rest))
+}
+
+// format writes rest field to res, items out of fieldNames are appended.
+func (r *restPlan) format(curStruct reflect.Value, header []string, res []string) []string {
+	field, ok := readFieldByIndex(curStruct, r.path)
+	if !ok || field.IsNil() {
+		return res
+	}
+
+	var cells []Cell
+	if r.cells {
+		cells = field.Interface().([]Cell)
+	} else {
+		rest := field.Interface().(map[string]string)
+		for _, i := range r.columns {
+			if v, ok := rest[header[i]]; ok {
+				cells = append(cells, Cell{Index: i, Column: header[i], Value: v})
+			}
+		}
+		for key, v := range rest {
+			i, err := strconv.Atoi(key)
+			if err == nil && i >= r.extraFrom {
+				cells = append(cells, Cell{Index: i, Value: v})
+			}
+		}
+	}
+
+	for _, cell := range cells {
+		if cell.Index < r.extraFrom && !r.isColumn(cell.Index) {
+			continue
+		}
+		for len(res) <= cell.Index {
+			res = append(res, "")
+		}
+		res[cell.Index] = cell.Value
+	}
+	return res
+}
+
+func (r *restPlan) isColumn(index int) bool {
+	for _, i := range r.columns {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Cell) key() string {
+	if c.Column != "" {
+		return c.Column
+	}
+	return strconv.Itoa(c.Index)
+}
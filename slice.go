@@ -0,0 +1,80 @@
+package slicetostruct
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+const defaultSliceSep = ","
+
+// ConvertSlice converts cell with separated values to slice field, like "red;green;blue" with sep=;
+// elements are trimmed and converted by converter of element type.
+type ConvertSlice struct {
+	elemType      reflect.Type
+	elemConverter Converter
+	elemFormatter Formatter
+}
+
+func (c *ConvertSlice) Set(value *ConvertValueParams) error {
+	item := value.Items[value.Index]
+	if strings.TrimSpace(item) == "" {
+		return nil
+	}
+	elements := strings.Split(item, sliceSep(value.Tags))
+	for i := range elements {
+		elements[i] = strings.TrimSpace(elements[i])
+	}
+
+	res := reflect.MakeSlice(value.ReflectValue.Type(), len(elements), len(elements))
+	params := ConvertValueParams{
+		Items:     elements,
+		Tags:      value.Tags,
+		FieldName: value.FieldName,
+		FieltType: c.elemType.String(),
+		options:   value.options,
+	}
+	for i := range elements {
+		v := res.Index(i)
+		params.Index = i
+		params.ReflectValue = &v
+		err := c.elemConverter.Set(&params)
+		if err != nil {
+			return errors.Wrapf(err, "cant elemConverter.Set, element = %d", i)
+		}
+	}
+	value.ReflectValue.Set(res)
+	return nil
+}
+
+func (c *ConvertSlice) Format(value *FormatValueParams) (string, error) {
+	if c.elemFormatter == nil {
+		return "", ErrFormatterDoesNotExist
+	}
+	v := *value.ReflectValue
+	res := make([]string, v.Len())
+	params := FormatValueParams{
+		Tags:      value.Tags,
+		FieldName: value.FieldName,
+		FieltType: c.elemType.String(),
+		options:   value.options,
+	}
+	for i := range res {
+		elem := v.Index(i)
+		params.ReflectValue = &elem
+		var err error
+		res[i], err = c.elemFormatter.Format(&params)
+		if err != nil {
+			return "", errors.Wrapf(err, "cant elemFormatter.Format, element = %d", i)
+		}
+	}
+	return strings.Join(res, sliceSep(value.Tags)), nil
+}
+
+func sliceSep(tags []string) string {
+	if sep, ok := tagOption(tags, "sep"); ok && sep != "" {
+		return sep
+	}
+	return defaultSliceSep
+}
@@ -3,9 +3,7 @@ package slicetostruct
 import (
 	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
-	"time"
 
 	"github.com/go-faster/errors"
 )
@@ -15,58 +13,167 @@ var ErrIndexDoesNotExist = fmt.Errorf("index of field does not exist")
 const keyTag = "ss"
 const defaultTimeLayout = "02.01.2006"
 
+// items of null value for converter
+var nullItems = []string{""}
+
 type SliceToStruct[T any] struct {
 	Params
 	fieldNames map[string]int
+	// fieldNames as is, for names of rest field
+	header []string
+	plan   *plan
 }
 
+// Strictness defines what to do with columns which are not mapped to fields.
+type Strictness int
+
+const (
+	// row can not be longer than fieldNames, unknown columns of fieldNames are ignored
+	StrictnessDefault Strictness = iota
+	// unknown columns of fieldNames and extra cells of row are ErrUnknownColumn
+	StrictnessStrict
+	// unknown columns and extra cells are ignored
+	StrictnessLenient
+	// unknown columns and extra cells are collected to field with rest tag, like lenient otherwise
+	StrictnessCollect
+)
+
 type Params struct {
-	// replace comma to dot for float value
+	// decimal separator is comma for integer and float values, if NumberFormat.Decimal is empty
 	ReplaceCommaToDot          bool
 	ReturnErrIndexDoesNotExist bool
 	FieldNames                 []string
 	NotCaseSensitive           bool
-	converters                 *converters
+	// integer may have base prefix 0x, 0o, 0b and underscores, like go literals
+	AllowIntBasePrefix bool
+	// accepted values of bool fields, case insensitive,
+	// default true, 1, t and false, 0, f, first one is used by ToSlice
+	TrueTokens  []string
+	FalseTokens []string
+	// default value of empty or missing field, if ss tag does not have default option,
+	// it is called once per field on New and SetFieldNames
+	DefaultValue func(field FieldContext) (string, bool)
+	Strictness   Strictness
+	// collect errors of all fields in RowError instead of return first one,
+	// partially filled struct is returned with RowError
+	CollectErrors bool
+	// layouts of time fields without layout in tag, tried in order, first one is used by ToSlice,
+	// default 02.01.2006
+	TimeLayouts []string
+	// decimal and grouping separators of integer and float fields, including sql.Null*
+	NumberFormat NumberFormat
+	// values which are null like empty string for pointer, sql.Null* and omitempty fields,
+	// like NULL or N/A, case insensitive, field can override them by tag option null=NULL|N/A
+	NullTokens []string
+	// value of nil pointer and invalid sql.Null* for ToSlice, first token of null tag option is used for field with it
+	NullOutput string
+	converters *converters
 }
 
 func New[T any](params Params) *SliceToStruct[T] {
-	if params.converters == nil {
-		params.converters = &converters{}
-		params.converters.SetConverter("int64", &ConvertInt64{})
-		params.converters.SetConverter("*int64", &ConvertNullInt64{})
-		params.converters.SetConverter("int", &ConvertInt{})
-		convertSqlValue := ConvertSqlValue{
-			params: &params,
-		}
-		params.converters.SetConverter("sql.NullInt64", &convertSqlValue)
-		params.converters.SetConverter("sql.NullFloat64", &convertSqlValue)
-		params.converters.SetConverter("sql.NullString", &convertSqlValue)
-		params.converters.SetConverter("sql.NullInt32", &convertSqlValue)
-		params.converters.SetConverter("sql.NullInt16", &convertSqlValue)
-		params.converters.SetConverter("sql.NullByte", &convertSqlValue)
-		params.converters.SetConverter("sql.NullBool", &convertSqlValue)
-		params.converters.SetConverter("sql.NullTime", &convertSqlValue)
-	}
-
 	sTS := &SliceToStruct[T]{
 		Params: params,
 	}
+	if sTS.converters == nil {
+		sTS.converters = newConverters(&sTS.Params)
+	}
 	sTS.SetFieldNames(params.FieldNames)
 	return sTS
 }
 
+func newConverters(params *Params) *converters {
+	c := &converters{
+		params: params,
+	}
+	convertInteger := ConvertInteger{
+		params: params,
+	}
+	for _, name := range []string{"int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64"} {
+		c.SetConverter(name, &convertInteger)
+		c.SetConverter("*"+name, &convertInteger)
+	}
+	convertFloat := ConvertFloat{
+		params: params,
+	}
+	for _, name := range []string{"float32", "float64"} {
+		c.SetConverter(name, &convertFloat)
+		c.SetConverter("*"+name, &convertFloat)
+	}
+	convertBool := ConvertBool{
+		params: params,
+	}
+	c.SetConverter("bool", &convertBool)
+	c.SetConverter("*bool", &convertBool)
+	convertString := ConvertString{}
+	c.SetConverter("string", &convertString)
+	c.SetConverter("*string", &convertString)
+	convertDecimal := ConvertDecimal{
+		params: params,
+	}
+	c.SetTypeConverter(decimalType, &convertDecimal)
+	c.SetTypeConverter(reflect.PtrTo(decimalType), &convertDecimal)
+	c.SetConverter("time.Time", &ConvertTime{
+		params: params,
+	})
+	c.SetConverter("*time.Time", &ConvertNullTime{
+		params: params,
+	})
+	convertSqlValue := ConvertSqlValue{
+		params: params,
+	}
+	c.SetConverter("sql.NullInt64", &convertSqlValue)
+	c.SetConverter("sql.NullFloat64", &convertSqlValue)
+	c.SetConverter("sql.NullString", &convertSqlValue)
+	c.SetConverter("sql.NullInt32", &convertSqlValue)
+	c.SetConverter("sql.NullInt16", &convertSqlValue)
+	c.SetConverter("sql.NullByte", &convertSqlValue)
+	c.SetConverter("sql.NullBool", &convertSqlValue)
+	c.SetConverter("sql.NullTime", &convertSqlValue)
+
+	c.kinds = map[reflect.Kind]Converter{
+		reflect.String:  &convertString,
+		reflect.Bool:    &convertBool,
+		reflect.Float32: &convertFloat,
+		reflect.Float64: &convertFloat,
+	}
+	for _, kind := range []reflect.Kind{
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+	} {
+		c.kinds[kind] = &convertInteger
+	}
+	return c
+}
+
 func (sTS *SliceToStruct[T]) SetConverter(name string, converter Converter) {
 	sTS.converters.SetConverter(name, converter)
+	sTS.compile()
 }
 
-func (sTS *SliceToStruct[T]) SetFieldNames(fieldNames []string) {
+// SetTypeConverter sets converter by reflect.Type, it has priority over converter set by type name.
+func (sTS *SliceToStruct[T]) SetTypeConverter(fieldType reflect.Type, converter Converter) {
+	sTS.converters.SetTypeConverter(fieldType, converter)
+	sTS.compile()
+}
+
+func (sTS *SliceToStruct[T]) SetTypeFormatter(fieldType reflect.Type, formatter Formatter) {
+	sTS.converters.SetTypeFormatter(fieldType, formatter)
+	sTS.compile()
+}
+
+// SetFieldNames sets names of slice items, returns Err, like HeaderError of missing required column.
+func (sTS *SliceToStruct[T]) SetFieldNames(fieldNames []string) error {
 	copyFieldNames := make([]string, len(fieldNames))
 	copy(copyFieldNames, fieldNames)
 
 	if len(fieldNames) == 0 {
 		sTS.fieldNames = nil
-		return
+		sTS.header = nil
+		sTS.compile()
+		return sTS.Err()
 	}
+	sTS.header = make([]string, len(fieldNames))
+	copy(sTS.header, fieldNames)
 
 	fieldNamesMap := make(map[string]int, len(copyFieldNames))
 	for i := range copyFieldNames {
@@ -76,139 +183,232 @@ func (sTS *SliceToStruct[T]) SetFieldNames(fieldNames []string) {
 		fieldNamesMap[copyFieldNames[i]] = i
 	}
 	sTS.fieldNames = fieldNamesMap
+	sTS.compile()
+	return sTS.Err()
+}
+
+// Err returns error of struct definition, like unknown converter in ss tag,
+// it is checked on New, SetFieldNames and SetConverter and returned by every ToStruct call.
+func (sTS *SliceToStruct[T]) Err() error {
+	return sTS.plan.err
 }
 
 func (sTS *SliceToStruct[T]) ToStruct(items []string) (*T, error) {
-	if len(sTS.fieldNames) > 0 && len(sTS.fieldNames) < len(items) {
-		return nil, errors.New("count items greater then fieldNames")
+	if sTS.plan.err != nil {
+		return nil, sTS.plan.err
+	}
+	if len(items) > sTS.lenSlice() {
+		switch sTS.Strictness {
+		case StrictnessDefault:
+			if len(sTS.header) > 0 {
+				return nil, errors.New("count items greater then fieldNames")
+			}
+		case StrictnessStrict:
+			return nil, &FieldError{
+				SliceIndex: sTS.lenSlice(),
+				Value:      items[sTS.lenSlice()],
+				Cause:      ErrUnknownColumn,
+				Err:        errors.Errorf("count items %d greater then %d", len(items), sTS.lenSlice()),
+			}
+		}
 	}
 
-	var val interface{} = new(T)
+	var val T
+	curStruct := reflect.ValueOf(&val).Elem()
 
-	pointToStruct := reflect.ValueOf(val)
-	curStruct := pointToStruct.Elem()
-	kind := curStruct.Kind()
-	if kind != reflect.Struct {
-		return nil, errors.New("generic type does not struct")
+	var field reflect.Value
+	params := ConvertValueParams{
+		ReflectValue: &field,
 	}
+	var rowErr *RowError
+	for i := range sTS.plan.fields {
+		fp := &sTS.plan.fields[i]
 
-	structType := pointToStruct.Elem().Type()
-	for i := 0; i < structType.NumField(); i++ {
-		fieldInfo := structType.Field(i)
-		sliceFieldName := fieldInfo.Name
-		tags := getTags(fieldInfo.Tag.Get(keyTag))
-		if len(tags) > 0 && tags[0] != "" {
-			sliceFieldName = tags[0]
-		}
-		if sTS.NotCaseSensitive {
-			sliceFieldName = strings.ToLower(sliceFieldName)
-		}
-		if sliceFieldName == "-" {
+		var fieldErr *FieldError
+		if fp.columns != nil {
+			if fp.settable {
+				fieldErr = fp.setColumns(curStruct, items)
+			}
+			if fieldErr != nil {
+				if !sTS.CollectErrors {
+					return nil, fieldErr
+				}
+				rowErr = rowErr.append(fieldErr)
+			}
 			continue
 		}
-
-		fieldIndex, err := sTS.GetSliceIndexForField(sliceFieldName, i, len(items))
-		if err != nil && !errors.Is(err, ErrIndexDoesNotExist) {
-			return nil, errors.Wrap(err, "")
+		fieldIndex, err := sTS.fieldSliceIndex(fp, len(items))
+		item := ""
+		if err == nil {
+			item = items[fieldIndex]
+		} else {
+			fieldIndex = sTS.expectedSliceIndex(fp)
 		}
-		if errors.Is(err, ErrIndexDoesNotExist) {
-			if sTS.ReturnErrIndexDoesNotExist {
-				return nil, ErrIndexDoesNotExist
+		hasDefault := fp.defaultItems != nil
+		null := fp.isNull(item)
+		switch {
+		case fp.required && (err != nil || null):
+			fieldErr = newFieldError(fp, fieldIndex, item, ErrRequired, errors.New("required value is empty"))
+		case errors.Is(err, ErrIndexDoesNotExist) && !hasDefault:
+			if !sTS.ReturnErrIndexDoesNotExist {
+				continue
 			}
+			fieldErr = newFieldError(fp, fieldIndex, "", ErrMissingColumn, err)
+		case err != nil && !hasDefault:
+			fieldErr = newFieldError(fp, fieldIndex, "", ErrMissingColumn, err)
+		case !fp.settable:
 			continue
+		case hasDefault && null:
+			field = fieldByIndex(curStruct, fp.path)
+			err = fp.set(&params, fp.defaultItems, 0)
+			if err != nil {
+				fieldErr = newFieldError(fp, fieldIndex, fp.defaultItems[0], ErrParse, err)
+			}
+		case (fp.pointer || fp.nestedPointer) && null:
+			continue
+		case fp.omitEmpty && null:
+			continue
+		case fp.nullable && null:
+			field = fieldByIndex(curStruct, fp.path)
+			err = fp.set(&params, nullItems, 0)
+			if err != nil {
+				fieldErr = newFieldError(fp, fieldIndex, item, ErrParse, err)
+			}
+		case fp.converter == nil:
+			fieldErr = newFieldError(fp, fieldIndex, item, ErrUnsupportedType, errors.Errorf("type not implement %s", fp.fieldType))
+		default:
+			field = fieldByIndex(curStruct, fp.path)
+			err = fp.set(&params, items, fieldIndex)
+			if err != nil {
+				fieldErr = newFieldError(fp, fieldIndex, item, ErrParse, err)
+			} else if fp.currencyPath != nil {
+				symbol, suffix := currencySymbol(fp.tags, item)
+				fieldByIndex(curStruct, fp.currencyPath).SetString(symbol)
+				if symbol != "" {
+					fp.currencySuffix.Store(symbol, suffix)
+				}
+			}
 		}
+		if fieldErr == nil {
+			continue
+		}
+		if !sTS.CollectErrors {
+			return nil, fieldErr
+		}
+		rowErr = rowErr.append(fieldErr)
+	}
+	if sTS.plan.rest != nil {
+		sTS.plan.rest.set(curStruct, sTS.header, items)
+	}
+	if rowErr != nil {
+		return &val, rowErr
+	}
+	return &val, nil
+}
+
+// lenSlice returns count of fieldNames or count of struct fields if fieldNames are empty.
+func (sTS *SliceToStruct[T]) lenSlice() int {
+	if len(sTS.header) > 0 {
+		return len(sTS.header)
+	}
+	return sTS.plan.numFields
+}
+
+// ToSlice is the reverse of ToStruct, it renders struct to slice using same tags, fieldNames and converters.
+func (sTS *SliceToStruct[T]) ToSlice(item *T) ([]string, error) {
+	if item == nil {
+		return nil, errors.New("item is nil")
+	}
+	if sTS.plan.err != nil {
+		return nil, sTS.plan.err
+	}
 
-		fieldType := fieldInfo.Type.String()
-		field := curStruct.FieldByName(fieldInfo.Name)
-		if !field.IsValid() || !field.CanSet() {
+	curStruct := reflect.ValueOf(item).Elem()
+	lenSlice := sTS.lenSlice()
+	res := make([]string, lenSlice)
+
+	var field reflect.Value
+	params := FormatValueParams{
+		ReflectValue: &field,
+	}
+	for i := range sTS.plan.fields {
+		fp := &sTS.plan.fields[i]
+
+		if fp.columns != nil {
+			field, ok := readFieldByIndex(curStruct, fp.path)
+			if !fp.settable || !ok {
+				continue
+			}
+			err := fp.formatColumns(field, res)
+			if err != nil {
+				return nil, err
+			}
 			continue
 		}
-		if fieldType[0] == '*' && items[fieldIndex] == "" {
+		fieldIndex, err := sTS.fieldSliceIndex(fp, lenSlice)
+		if err != nil {
+			return nil, errors.Wrap(err, "")
+		}
+		if !fp.settable {
 			continue
 		}
-		if len(tags) > 1 && tags[1] == "omitempty" && items[fieldIndex] == "" {
+		var ok bool
+		field, ok = readFieldByIndex(curStruct, fp.path)
+		if !ok || (fp.omitEmpty && field.IsZero()) {
 			continue
 		}
 
-		errInfo := fmt.Sprintf("field = %s, fieldValue = %s, index = %d", sliceFieldName, items[fieldIndex], i)
-
-		converter, err := sTS.converters.GetConverter(fieldType)
-		if err != nil && !errors.Is(err, ErrConverterDoesNotExist) {
-			return nil, errors.Wrapf(err, "cant sTS.converters.GetConverter. %s", errInfo)
+		if fp.formatter == nil {
+			return nil, errors.Wrapf(ErrFormatterDoesNotExist, "cant sTS.converters.GetFormatter, type = %s. field = %s, index = %d", fp.fieldType, fp.sliceName, fieldIndex)
 		}
-		if err == nil {
-			err = converter.Set(&ConvertValueParams{
-				Items:        items,
-				Index:        fieldIndex,
-				ReflectValue: &field,
-				Tags:         tags,
-				FieldName:    &sliceFieldName,
-				FieltType:    fieldType,
-			})
-			if err != nil {
-				return nil, errors.Wrapf(err, "cant converter.Set. %s", errInfo)
+		params.Tags = fp.tags
+		params.options = fp.options
+		if fp.currencyPath != nil {
+			if currency, ok := readFieldByIndex(curStruct, fp.currencyPath); ok && currency.String() != "" {
+				params.Tags = currencyTags(fp, currency.String())
+				params.options = nil
 			}
+		}
+		params.FieldName = &fp.sliceName
+		params.FieltType = fp.fieldType
+		if fp.nullOutput != "" && isNullValue(field) {
+			res[fieldIndex] = fp.nullOutput
 			continue
 		}
+		res[fieldIndex], err = fp.formatter.Format(&params)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cant formatter.Format. field = %s, index = %d", fp.sliceName, fieldIndex)
+		}
+	}
+	if sTS.plan.rest != nil {
+		res = sTS.plan.rest.format(curStruct, sTS.header, res)
+	}
+	return res, nil
+}
 
-		switch fieldType {
-		case "*int":
-			v, err := strconv.ParseInt(items[fieldIndex], 10, 64)
-			if err != nil {
-				return nil, errors.Wrapf(err, "%s", errInfo)
-			}
-			vInt := int(v)
-			field.Set(reflect.ValueOf(&vInt))
-		case "string":
-			field.SetString(items[fieldIndex])
-		case "*string":
-			v := items[fieldIndex]
-			field.Set(reflect.ValueOf(&v))
-		case "float64":
-			if sTS.Params.ReplaceCommaToDot {
-				items[fieldIndex] = strings.Replace(items[fieldIndex], ",", ".", 1)
-			}
-			v, err := strconv.ParseFloat(items[fieldIndex], 64)
-			if err != nil {
-				return nil, errors.Wrapf(err, "%s", errInfo)
-			}
-			field.Set(reflect.ValueOf(v))
-		case "*float64":
-			if sTS.Params.ReplaceCommaToDot {
-				items[fieldIndex] = strings.Replace(items[fieldIndex], ",", ".", 1)
-			}
-			v, err := strconv.ParseFloat(items[fieldIndex], 64)
-			if err != nil {
-				return nil, errors.Wrapf(err, "%s", errInfo)
-			}
-			field.Set(reflect.ValueOf(&v))
-		case "time.Time":
-			timeLayout := defaultTimeLayout
-			if len(tags) > 2 {
-				timeLayout = tags[2]
-			}
-			t, err := time.Parse(timeLayout, items[fieldIndex])
-			if err != nil {
-				return nil, errors.Wrapf(err, "%s", errInfo)
-			}
-			field.Set(reflect.ValueOf(t))
-		case "*time.Time":
-			timeLayout := defaultTimeLayout
-			if len(tags) > 2 {
-				timeLayout = tags[2]
-			}
-			t, err := time.Parse(timeLayout, items[fieldIndex])
-			if err != nil {
-				return nil, errors.Wrapf(err, "%s", errInfo)
-			}
-			field.Set(reflect.ValueOf(&t))
-		default:
-			return nil, errors.Wrapf(errors.New(fmt.Sprintf("type not implement %s", fieldType)), "%s", errInfo)
+// expectedSliceIndex returns index of field on slice, which may be out of slice, -1 if fieldNames does not have field.
+func (sTS *SliceToStruct[T]) expectedSliceIndex(fp *fieldPlan) int {
+	if len(sTS.fieldNames) > 0 {
+		return fp.sliceIndex
+	}
+	return fp.position
+}
+
+func (sTS *SliceToStruct[T]) fieldSliceIndex(fp *fieldPlan, lenSlice int) (int, error) {
+	if len(sTS.fieldNames) > 0 {
+		if fp.sliceIndex < 0 {
+			return 0, errors.Errorf("fieldName does not exist on fieldNames. fieldName = %s, fieldNames = %v", fp.sliceName, sTS.fieldNames)
+		}
+		if fp.sliceIndex > (lenSlice - 1) {
+			return 0, errors.Errorf("fieldName index does not exist on slice, fieldName = %s, index = %d", fp.sliceName, fp.sliceIndex)
 		}
+		return fp.sliceIndex, nil
+	}
 
+	if lenSlice < fp.position+1 {
+		return 0, ErrIndexDoesNotExist
 	}
-	v := curStruct.Interface().(T)
-	return &v, nil
+	return fp.position, nil
 }
 
 func (sTS *SliceToStruct[T]) GetSliceIndexForField(fieldName string, fieldIndex int, lenSlice int) (int, error) {
@@ -231,19 +431,6 @@ func (sTS *SliceToStruct[T]) GetSliceIndexForField(fieldName string, fieldIndex
 }
 
 func getTags(tagStr string) []string {
-
-	res := strings.Split(tagStr, ",")
-	for i := range res {
-		if len(res) >= (i+1) && strings.HasSuffix(res[i], `#`) {
-			res[i] = res[i][:len(res[i])-1] + "," + res[i+1]
-
-			if len(res) >= (i + 2) {
-				res = append(res[:i+1], res[i+2:]...)
-				continue
-			}
-			res = res[:len(res)-1]
-		}
-	}
-
+	res, _ := splitTag(tagStr)
 	return res
 }
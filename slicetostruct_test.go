@@ -2,7 +2,11 @@ package slicetostruct
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -385,3 +389,1698 @@ func TestSqlNullTime(t *testing.T) {
 		t.Error("res.Date is wrong")
 	}
 }
+
+type TSql struct {
+	Int64   sql.NullInt64   `ss:"int64"`
+	Float64 sql.NullFloat64 `ss:"float64"`
+	String  sql.NullString  `ss:"string"`
+	Int32   sql.NullInt32   `ss:"int32"`
+	Int16   sql.NullInt16   `ss:"int16"`
+	Byte    sql.NullByte    `ss:"byte"`
+	Bool    sql.NullBool    `ss:"bool"`
+	Time    sql.NullTime    `ss:"time"`
+}
+
+func TestToSlice(t *testing.T) {
+	fieldNames := []string{
+		"id", "name", "int", "id_nil", "name_nil", "int_nil", "float_64", "float_64_nil", "time", "time_nil",
+	}
+	row := []string{"123", "name test", "1", "1232", "name test_2", "12", "23.1", "23.2", "01.01.2012", "03.03.2003"}
+	sliceToStruct := New[TAll](Params{
+		FieldNames: fieldNames,
+	})
+	res, err := sliceToStruct.ToStruct(row)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint(row) {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	slice, err = sliceToStruct.ToSlice(&TAll{})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if slice[3] != "" || slice[4] != "" || slice[5] != "" || slice[7] != "" || slice[9] != "" {
+		t.Errorf("nil pointers should be empty %v", slice)
+	}
+
+	sliceToStruct.SetFieldNames([]string{"name", "id"})
+	_, err = sliceToStruct.ToSlice(res)
+	if err == nil {
+		t.Error("should has error, field does not exist on fieldNames")
+	}
+}
+
+func TestToSliceTags(t *testing.T) {
+	sliceToStruct := New[T4](Params{})
+	slice, err := sliceToStruct.ToSlice(&T4{ID: 0, ID2: 0, ID3: 4})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if len(slice) != 3 || slice[0] != "0" || slice[1] != "" || slice[2] != "4" {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	sliceToStruct2 := New[T6](Params{})
+	sliceToStruct2.SetFieldNames([]string{"fake", "id", "id2", "ss"})
+	slice, err = sliceToStruct2.ToSlice(&T6{ID: 1, ID2: 2, ID1_2: 3})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint([]string{"", "1", "2", ""}) {
+		t.Errorf("wrong result %v", slice)
+	}
+}
+
+func TestToSliceSql(t *testing.T) {
+	sliceToStruct := New[TSql](Params{
+		ReplaceCommaToDot: true,
+	})
+	row := []string{"1", "2,5", "str", "3", "4", "5", "true", "01.02.2002"}
+	res, err := sliceToStruct.ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint(row) {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	slice, err = sliceToStruct.ToSlice(&TSql{})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint(make([]string, 8)) {
+		t.Errorf("invalid values should be empty %v", slice)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	data := "\ufeffID2;id;fake\n# comment\n2;1;x\n\"4\";3;y\n"
+	decoder := NewDecoder[T3](strings.NewReader(data), DecoderParams{
+		Params: Params{
+			NotCaseSensitive: true,
+		},
+		Comma:   ';',
+		Comment: '#',
+	})
+	var res []*T3
+	for decoder.Next() {
+		res = append(res, decoder.Value())
+	}
+	if err := decoder.Err(); err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if len(res) != 2 || res[0].ID != 1 || res[0].ID2 != 2 || res[1].ID != 3 || res[1].ID2 != 4 {
+		t.Error("wrong result")
+		return
+	}
+
+	data = "id,id2\n1,2\n3,bad\n5,6\n"
+	decoder = NewDecoder[T3](strings.NewReader(data), DecoderParams{})
+	count := 0
+	for decoder.Next() {
+		count++
+	}
+	if count != 1 || decoder.Err() == nil || decoder.Line() != 3 {
+		t.Errorf("should has error on line 3, count = %d, line = %d, err = %v", count, decoder.Line(), decoder.Err())
+		return
+	}
+	if !strings.Contains(decoder.Err().Error(), "line 3") {
+		t.Errorf("error should contain line, %v", decoder.Err())
+	}
+
+	// with CollectErrors every bad row is reported with partial struct
+	decoder = NewDecoder[T3](strings.NewReader("id,id2\n1,2\n3,bad\nbad,6\n7,8\n"), DecoderParams{
+		Params: Params{
+			CollectErrors: true,
+		},
+	})
+	var lines []int
+	res = res[:0]
+	for decoder.Next() {
+		res = append(res, decoder.Value())
+		var rowErr *RowError
+		if decoder.RowErr() != nil {
+			if !errors.As(decoder.RowErr(), &rowErr) || !errors.Is(decoder.RowErr(), ErrParse) {
+				t.Errorf("wrong result %v", decoder.RowErr())
+			}
+			lines = append(lines, decoder.Line())
+		}
+	}
+	if decoder.Err() != nil || fmt.Sprint(lines) != "[3 4]" || len(res) != 4 {
+		t.Errorf("wrong result %v, %v, %d", decoder.Err(), lines, len(res))
+		return
+	}
+	if res[1].ID != 3 || res[2].ID2 != 6 || res[3].ID != 7 {
+		t.Errorf("wrong result %+v, %+v, %+v", res[1], res[2], res[3])
+	}
+
+	decoder = NewDecoder[T3](strings.NewReader("10,20\n"), DecoderParams{
+		Params: Params{
+			FieldNames: []string{"id", "id2"},
+		},
+	})
+	if !decoder.Next() || decoder.Value().ID != 10 || decoder.Value().ID2 != 20 || decoder.Next() {
+		t.Errorf("wrong result, %v", decoder.Err())
+	}
+
+	// fieldNames without required column stop decoder before first record
+	for _, collectErrors := range []bool{false, true} {
+		decoder2 := NewDecoder[TRequired](strings.NewReader("x,1\ny,2\n"), DecoderParams{
+			Params: Params{
+				FieldNames:    []string{"notes", "id"},
+				CollectErrors: collectErrors,
+			},
+		})
+		var headerErr *HeaderError
+		if decoder2.Next() || !errors.Is(decoder2.Err(), ErrRequired) || !errors.As(decoder2.Err(), &headerErr) || decoder2.RowErr() != nil {
+			t.Errorf("should has ErrRequired, %v, %v", decoder2.Err(), decoder2.RowErr())
+		}
+	}
+}
+
+func BenchmarkToStruct(b *testing.B) {
+	sliceToStruct := New[TAll](Params{
+		FieldNames: []string{
+			"id", "name", "int", "id_nil", "name_nil", "int_nil", "float_64", "float_64_nil", "time", "time_nil",
+		},
+	})
+	row := []string{"123", "name test", "1", "1232", "name test_2", "12", "23.1", "23.2", "01.01.2012", "03.03.2003"}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := sliceToStruct.ToStruct(row)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkToStructPositional(b *testing.B) {
+	sliceToStruct := New[T6](Params{})
+	row := []string{"1", "123", "33"}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := sliceToStruct.ToStruct(row)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkToSlice(b *testing.B) {
+	sliceToStruct := New[TAll](Params{
+		FieldNames: []string{
+			"id", "name", "int", "id_nil", "name_nil", "int_nil", "float_64", "float_64_nil", "time", "time_nil",
+		},
+	})
+	res, err := sliceToStruct.ToStruct([]string{"123", "name test", "1", "1232", "name test_2", "12", "23.1", "23.2", "01.01.2012", "03.03.2003"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := sliceToStruct.ToSlice(res)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestCollectErrors(t *testing.T) {
+	sliceToStruct := New[TAll](Params{
+		FieldNames: []string{
+			"id", "name", "int", "id_nil", "name_nil", "int_nil", "float_64", "float_64_nil", "time", "time_nil",
+		},
+		CollectErrors: true,
+	})
+	res, err := sliceToStruct.ToStruct([]string{"bad", "name test", "1", "x", "", "", "23.1", "", "01.01.2012", "2012"})
+	if err == nil {
+		t.Error("should has error")
+		return
+	}
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) {
+		t.Errorf("should be RowError, %v", err)
+		return
+	}
+	if len(rowErr.Errors) != 3 ||
+		rowErr.Errors[0].Field != "ID" || rowErr.Errors[0].Column != "id" || rowErr.Errors[0].SliceIndex != 0 || rowErr.Errors[0].Value != "bad" || rowErr.Errors[0].Type != "int64" ||
+		rowErr.Errors[1].Field != "IDNil" || rowErr.Errors[1].SliceIndex != 3 ||
+		rowErr.Errors[2].Field != "TimeNil" || rowErr.Errors[2].SliceIndex != 9 || rowErr.Errors[2].Value != "2012" {
+		t.Errorf("wrong errors %v", rowErr)
+	}
+	if res == nil || res.Name != "name test" || res.Int != 1 || res.Float64 != 23.1 {
+		t.Errorf("partially filled struct should be returned, %v", res)
+	}
+
+	sliceToStruct.SetFieldNames([]string{"id", "name"})
+	_, err = sliceToStruct.ToStruct([]string{"1", "name"})
+	if !errors.As(err, &rowErr) || len(rowErr.Errors) != 8 {
+		t.Errorf("missing fieldNames should be collected, %v", err)
+	}
+}
+
+type TUnsupported struct {
+	ID    int64
+	Value complex64
+}
+
+func TestFieldError(t *testing.T) {
+	sliceToStruct := New[T3](Params{
+		FieldNames: []string{"fake", "id", "id2"},
+	})
+	_, err := sliceToStruct.ToStruct([]string{"0", "1", "bad"})
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Errorf("should be FieldError, %v", err)
+		return
+	}
+	if fieldErr.Field != "ID2" || fieldErr.Column != "id2" || fieldErr.SliceIndex != 2 || fieldErr.Value != "bad" || fieldErr.Type != "int64" {
+		t.Errorf("wrong FieldError %+v", fieldErr)
+	}
+	if !errors.Is(err, ErrParse) || errors.Is(err, ErrMissingColumn) || !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("wrong cause, %v", err)
+	}
+
+	sliceToStruct.SetFieldNames([]string{"fake", "id"})
+	_, err = sliceToStruct.ToStruct([]string{"0", "1"})
+	if !errors.Is(err, ErrMissingColumn) || !errors.As(err, &fieldErr) || fieldErr.Field != "ID2" || fieldErr.SliceIndex != -1 {
+		t.Errorf("should be ErrMissingColumn, %v", err)
+	}
+
+	sliceToStruct2 := New[T2](Params{
+		ReturnErrIndexDoesNotExist: true,
+	})
+	_, err = sliceToStruct2.ToStruct([]string{"1"})
+	if !errors.Is(err, ErrMissingColumn) || !errors.Is(err, ErrIndexDoesNotExist) {
+		t.Errorf("should be ErrMissingColumn, %v", err)
+	}
+
+	sliceToStruct3 := New[TUnsupported](Params{})
+	_, err = sliceToStruct3.ToStruct([]string{"1", "2"})
+	if !errors.Is(err, ErrUnsupportedType) || !errors.As(err, &fieldErr) || fieldErr.Field != "Value" || fieldErr.SliceIndex != 1 {
+		t.Errorf("should be ErrUnsupportedType, %v", err)
+	}
+}
+
+type TInts struct {
+	Int8      int8
+	Int16     int16
+	Int32     int32
+	Uint      uint
+	Uint8     uint8
+	Uint16    uint16
+	Uint32    uint32
+	Uint64    uint64
+	Int32Nil  *int32
+	Uint64Nil *uint64
+}
+
+func TestIntegers(t *testing.T) {
+	sliceToStruct := New[TInts](Params{})
+	row := []string{"-128", "32767", "-5", "7", "255", "65535", "4294967295", "18446744073709551615", "", "42"}
+	res, err := sliceToStruct.ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Int8 != -128 || res.Int16 != 32767 || res.Int32 != -5 || res.Uint != 7 || res.Uint8 != 255 ||
+		res.Uint16 != 65535 || res.Uint32 != 4294967295 || res.Uint64 != 18446744073709551615 ||
+		res.Int32Nil != nil || *res.Uint64Nil != 42 {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint(row) {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	_, err = sliceToStruct.ToStruct([]string{"0", "0", "0", "0", "300"})
+	if !errors.Is(err, strconv.ErrRange) || !strings.Contains(err.Error(), "out of range for uint8") {
+		t.Errorf("should has overflow error, %v", err)
+	}
+	_, err = sliceToStruct.ToStruct([]string{"0", "0", "0", "-1"})
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("should has error, %v", err)
+	}
+	_, err = sliceToStruct.ToStruct([]string{"0x10"})
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("base prefix is not allowed by default, %v", err)
+	}
+
+	sliceToStruct = New[TInts](Params{
+		AllowIntBasePrefix: true,
+	})
+	res, err = sliceToStruct.ToStruct([]string{"0x10", "0o17", "-0b101", "1_000", "010"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Int8 != 16 || res.Int16 != 15 || res.Int32 != -5 || res.Uint != 1000 || res.Uint8 != 10 {
+		t.Errorf("wrong result %+v", res)
+	}
+}
+
+type TBool struct {
+	Bool    bool         `ss:"bool"`
+	BoolNil *bool        `ss:"bool_nil"`
+	SqlBool sql.NullBool `ss:"sql_bool"`
+	Checked bool         `ss:"checked,,true=x|✓,false="`
+}
+
+func TestBool(t *testing.T) {
+	sliceToStruct := New[TBool](Params{})
+	res, err := sliceToStruct.ToStruct([]string{"TRUE", "0", "t", ""})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if !res.Bool || res.BoolNil == nil || *res.BoolNil || !res.SqlBool.Valid || !res.SqlBool.Bool || res.Checked {
+		t.Errorf("wrong result %+v", res)
+	}
+	res, err = sliceToStruct.ToStruct([]string{"false", "", "", "✓"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Bool || res.BoolNil != nil || res.SqlBool.Valid || !res.Checked {
+		t.Errorf("wrong result %+v", res)
+	}
+
+	sliceToStruct = New[TBool](Params{
+		TrueTokens:  []string{"да", "yes", "Y"},
+		FalseTokens: []string{"нет", "no", "N"},
+	})
+	res, err = sliceToStruct.ToStruct([]string{"ДА", "n", "Yes", "X"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if !res.Bool || *res.BoolNil || !res.SqlBool.Bool || !res.Checked {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint([]string{"да", "нет", "да", "x"}) {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	_, err = sliceToStruct.ToStruct([]string{"true"})
+	if !errors.Is(err, ErrParse) || !strings.Contains(err.Error(), `"да" "yes" "Y"`) {
+		t.Errorf("error should list accepted tokens, %v", err)
+	}
+}
+
+type Status int
+
+func (s *Status) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "active":
+		*s = 1
+	case "blocked":
+		*s = 2
+	default:
+		return errors.Errorf("unknown status %s", text)
+	}
+	return nil
+}
+
+func (s Status) MarshalText() ([]byte, error) {
+	switch s {
+	case 1:
+		return []byte("active"), nil
+	case 2:
+		return []byte("blocked"), nil
+	}
+	return nil, nil
+}
+
+type Code struct {
+	Code string
+}
+
+func (c *Code) Scan(src interface{}) error {
+	v, ok := src.(string)
+	if !ok {
+		return errors.Errorf("unknown type %T", src)
+	}
+	c.Code = strings.ToUpper(v)
+	return nil
+}
+
+func (c *Code) Value() (driver.Value, error) {
+	return strings.ToLower(c.Code), nil
+}
+
+type TInterfaces struct {
+	Status    Status
+	StatusNil *Status
+	Code      Code
+	CodeNil   *Code
+}
+
+func TestTextUnmarshalerAndScanner(t *testing.T) {
+	sliceToStruct := New[TInterfaces](Params{})
+	row := []string{"active", "blocked", "ab", "cd"}
+	res, err := sliceToStruct.ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Status != 1 || *res.StatusNil != 2 || res.Code.Code != "AB" || res.CodeNil.Code != "CD" {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint(row) {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	res, err = sliceToStruct.ToStruct([]string{"active", "", "", ""})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.StatusNil != nil || res.CodeNil != nil {
+		t.Errorf("wrong result %+v", res)
+	}
+
+	_, err = sliceToStruct.ToStruct([]string{"unknown"})
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("should has error, %v", err)
+	}
+
+	sliceToStruct.SetConverter("slicetostruct.Status", &ConvertInteger{})
+	res, err = sliceToStruct.ToStruct([]string{"3"})
+	if err != nil || res.Status != 3 {
+		t.Errorf("registered converter should win, %v", err)
+	}
+}
+
+type UserID int64
+type Role string
+type Ratio float32
+type Flag bool
+type Level uint8
+
+type TNamed struct {
+	ID       UserID
+	Role     Role
+	RoleNil  *Role
+	Ratio    Ratio
+	Flag     Flag
+	LevelNil *Level
+	Float32  float32
+}
+
+func TestNamedTypes(t *testing.T) {
+	sliceToStruct := New[TNamed](Params{})
+	row := []string{"12", "admin", "user", "0.5", "true", "3", "1.25"}
+	res, err := sliceToStruct.ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.ID != 12 || res.Role != "admin" || *res.RoleNil != "user" || res.Ratio != 0.5 || !bool(res.Flag) || *res.LevelNil != 3 || res.Float32 != 1.25 {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint(row) {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	_, err = sliceToStruct.ToStruct([]string{"1", "", "", "0", "false", "256"})
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("should has overflow error, %v", err)
+	}
+
+	sliceToStruct.SetConverter("slicetostruct.Role", &roleConverter{})
+	res, err = sliceToStruct.ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Role != "ADMIN" || *res.RoleNil != "user" {
+		t.Errorf("registered converter should win %+v", res)
+	}
+}
+
+type roleConverter struct {
+}
+
+func (c *roleConverter) Set(value *ConvertValueParams) error {
+	value.ReflectValue.SetString(strings.ToUpper(value.Items[value.Index]))
+	return nil
+}
+
+type Money struct {
+	Cents int64
+}
+
+type ID string
+
+type TFunc struct {
+	Price    Money
+	PriceNil *Money
+	ID       ID
+}
+
+func TestRegisterFunc(t *testing.T) {
+	sliceToStruct := New[TFunc](Params{})
+	RegisterFunc(sliceToStruct, func(raw string, ctx FieldContext) (Money, error) {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return Money{}, err
+		}
+		return Money{Cents: int64(v * 100)}, nil
+	})
+	RegisterFormatFunc(sliceToStruct, func(v Money, ctx FieldContext) (string, error) {
+		return fmt.Sprintf("%d.%02d", v.Cents/100, v.Cents%100), nil
+	})
+	row := []string{"1.25", "3.50", "id1"}
+	res, err := sliceToStruct.ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Price.Cents != 125 || res.PriceNil.Cents != 350 || res.ID != "id1" {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint(row) {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	_, err = sliceToStruct.ToStruct([]string{"bad"})
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("should has error, %v", err)
+	}
+}
+
+func TestSetTypeConverter(t *testing.T) {
+	// same type name as package level ID
+	type ID string
+	type TID struct {
+		ID      ID
+		OtherID packageID
+	}
+
+	sliceToStruct := New[TID](Params{})
+	sliceToStruct.SetTypeConverter(reflect.TypeOf(ID("")), &roleConverter{})
+	res, err := sliceToStruct.ToStruct([]string{"a", "b"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.ID != "A" || res.OtherID != "b" {
+		t.Errorf("wrong result %+v", res)
+	}
+}
+
+type packageID = ID
+
+type kopecksConverter struct {
+}
+
+func (c *kopecksConverter) Set(value *ConvertValueParams) error {
+	v, err := strconv.ParseFloat(value.Items[value.Index], 64)
+	if err != nil {
+		return err
+	}
+	value.ReflectValue.SetInt(int64(v*100 + 0.5))
+	return nil
+}
+
+func (c *kopecksConverter) Format(value *FormatValueParams) (string, error) {
+	return strconv.FormatFloat(float64(value.ReflectValue.Int())/100, 'f', 2, 64), nil
+}
+
+type TConv struct {
+	Amount int64 `ss:"amount,conv=kopecks"`
+	Count  int64 `ss:"count"`
+}
+
+func TestTagConverter(t *testing.T) {
+	sliceToStruct := New[TConv](Params{})
+	if !errors.Is(sliceToStruct.Err(), ErrConverterDoesNotExist) {
+		t.Errorf("should has error, converter is not set, %v", sliceToStruct.Err())
+	}
+	_, err := sliceToStruct.ToStruct([]string{"1.5", "2"})
+	if !errors.Is(err, ErrConverterDoesNotExist) {
+		t.Errorf("should has error, converter is not set, %v", err)
+	}
+
+	sliceToStruct.SetConverter("kopecks", &kopecksConverter{})
+	if sliceToStruct.Err() != nil {
+		t.Errorf("%+v", sliceToStruct.Err())
+		return
+	}
+	row := []string{"1.50", "2"}
+	res, err := sliceToStruct.ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Amount != 150 || res.Count != 2 {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint(row) {
+		t.Errorf("wrong result %v", slice)
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	tag, err := parseTag(`'is, id',omitempty`)
+	if err != nil || tag.name != "is, id" || !tag.omitEmpty {
+		t.Errorf("wrong result %+v, %v", tag, err)
+	}
+	tag, err = parseTag(`date,,2006-01-02`)
+	if err != nil || tag.name != "date" || tag.omitEmpty || fmt.Sprint(tag.tags) != fmt.Sprint([]string{"date", "", "2006-01-02"}) {
+		t.Errorf("wrong result %+v, %v", tag, err)
+	}
+	tag, err = parseTag(`date,layout='Jan 2, 2006',tz=Europe/Moscow`)
+	if err != nil || tag.options["layout"] != "Jan 2, 2006" || tag.options["tz"] != "Europe/Moscow" ||
+		fmt.Sprint(tag.tags) != fmt.Sprint([]string{"date", "", "Jan 2, 2006", "tz=Europe/Moscow"}) {
+		t.Errorf("wrong result %+v, %v", tag, err)
+	}
+	tag, err = parseTag(`test#,test1,omitempty`)
+	if err != nil || tag.name != "test,test1" || !tag.omitEmpty {
+		t.Errorf("wrong result %+v, %v", tag, err)
+	}
+	tag, err = parseTag(`'it''s',conv=name`)
+	if err != nil || tag.name != "it's" || tag.options["conv"] != "name" {
+		t.Errorf("wrong result %+v, %v", tag, err)
+	}
+	tag, err = parseTag(``)
+	if err != nil || tag.name != "" {
+		t.Errorf("wrong result %+v, %v", tag, err)
+	}
+
+	for _, tagStr := range []string{
+		`id,omitemty`,
+		`id,2006-01-02`,
+		`id,,layout=2006,2006`,
+		`id,layot=2006`,
+		`id,=2006`,
+		`id,conv=a,conv=b`,
+		`'id,omitempty`,
+	} {
+		_, err = parseTag(tagStr)
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("should has error, %s, %v", tagStr, err)
+		}
+	}
+}
+
+type TTag struct {
+	ID   int64     `ss:"'is, id',omitempty"`
+	Date time.Time `ss:"date,layout=2006-01-02 15:04,tz=Europe/Moscow"`
+}
+
+type THashTag struct {
+	X    *string  `ss:"x,null=N/A|#,omitempty"`
+	Tags []string `ss:"tags,sep=#,omitempty"`
+}
+
+type TInvalidTag struct {
+	ID int64 `ss:"id,omitemty"`
+}
+
+func TestTagGrammar(t *testing.T) {
+	sliceToStruct := New[TTag](Params{
+		FieldNames: []string{"date", "is, id"},
+	})
+	row := []string{"2020-01-02 03:04", "5"}
+	res, err := sliceToStruct.ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.ID != 5 || res.Date.UTC().Format(time.RFC3339) != "2020-01-02T00:04:00Z" {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint(row) {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	for tagStr, want := range map[string][]string{
+		`x,null=N/A|#,omitempty`: {"x", "omitempty", "", "null=N/A|#"},
+		`tags,sep=#,omitempty`:   {"tags", "omitempty", "", "sep=#"},
+	} {
+		tag, err := parseTag(tagStr)
+		if err != nil || fmt.Sprint(tag.tags) != fmt.Sprint(want) {
+			t.Errorf("wrong result %s, %+v, %v", tagStr, tag, err)
+		}
+	}
+	sliceToStruct3 := New[THashTag](Params{})
+	res3, err := sliceToStruct3.ToStruct([]string{"#", "a#b"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res3.X != nil || fmt.Sprint(res3.Tags) != "[a b]" {
+		t.Errorf("wrong result %+v", res3)
+	}
+
+	sliceToStruct2 := New[TInvalidTag](Params{})
+	_, err = sliceToStruct2.ToStruct([]string{"1"})
+	if !errors.Is(err, ErrInvalidTag) || !errors.Is(sliceToStruct2.Err(), ErrInvalidTag) {
+		t.Errorf("should has error, %v", err)
+	}
+}
+
+type TDefault struct {
+	Qty    int64     `ss:"qty,default=1"`
+	Name   *string   `ss:"name,default=unknown"`
+	Date   time.Time `ss:"date,layout=2006-01-02,default=2000-01-01"`
+	Price  float64   `ss:"price"`
+	Amount float64   `ss:"amount,omitempty"`
+}
+
+type TInvalidDefault struct {
+	Qty int64 `ss:"qty,default=one"`
+}
+
+func TestDefault(t *testing.T) {
+	sliceToStruct := New[TDefault](Params{})
+	res, err := sliceToStruct.ToStruct([]string{"", "", "", "2.5"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Qty != 1 || *res.Name != "unknown" || res.Date.Year() != 2000 || res.Price != 2.5 {
+		t.Errorf("wrong result %+v", res)
+	}
+	res, err = sliceToStruct.ToStruct([]string{"3", "name"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Qty != 3 || *res.Name != "name" || res.Date.Year() != 2000 {
+		t.Errorf("wrong result %+v", res)
+	}
+
+	sliceToStruct.SetFieldNames([]string{"price"})
+	_, err = sliceToStruct.ToStruct([]string{"2.5"})
+	if !errors.Is(err, ErrMissingColumn) {
+		t.Errorf("amount does not have default, %v", err)
+	}
+
+	sliceToStruct = New[TDefault](Params{
+		FieldNames: []string{"price"},
+		DefaultValue: func(field FieldContext) (string, bool) {
+			if field.Type.Kind() == reflect.Float64 {
+				return "0", true
+			}
+			return "", false
+		},
+	})
+	res, err = sliceToStruct.ToStruct([]string{""})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Qty != 1 || *res.Name != "unknown" || res.Price != 0 || res.Amount != 0 {
+		t.Errorf("wrong result %+v", res)
+	}
+
+	sliceToStruct2 := New[TInvalidDefault](Params{})
+	if !errors.Is(sliceToStruct2.Err(), strconv.ErrSyntax) {
+		t.Errorf("invalid default should fail on New, %v", sliceToStruct2.Err())
+	}
+}
+
+type TRequired struct {
+	ID    int64   `ss:"id,required"`
+	Name  *string `ss:"name,required"`
+	Notes string  `ss:"notes"`
+}
+
+type TRequiredDefault struct {
+	ID int64 `ss:"id,required,default=1"`
+}
+
+func TestRequired(t *testing.T) {
+	sliceToStruct := New[TRequired](Params{})
+	res, err := sliceToStruct.ToStruct([]string{"1", "name"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.ID != 1 || *res.Name != "name" {
+		t.Errorf("wrong result %+v", res)
+	}
+
+	var fieldErr *FieldError
+	_, err = sliceToStruct.ToStruct([]string{"1", ""})
+	if !errors.Is(err, ErrRequired) || !errors.As(err, &fieldErr) || fieldErr.Field != "Name" || fieldErr.SliceIndex != 1 {
+		t.Errorf("should has ErrRequired, %v", err)
+	}
+	_, err = sliceToStruct.ToStruct([]string{"1"})
+	if !errors.Is(err, ErrRequired) || !errors.As(err, &fieldErr) || fieldErr.Field != "Name" || fieldErr.SliceIndex != 1 {
+		t.Errorf("should has ErrRequired, %v", err)
+	}
+
+	err = sliceToStruct.SetFieldNames([]string{"notes", "id"})
+	var headerErr *HeaderError
+	if !errors.Is(err, ErrRequired) || !errors.As(err, &headerErr) {
+		t.Errorf("should has ErrRequired, %v", err)
+		return
+	}
+	if len(headerErr.Errors) != 1 || headerErr.Errors[0].Column != "name" || !errors.Is(&headerErr.Errors[0], ErrRequired) {
+		t.Errorf("wrong errors %v", headerErr)
+	}
+	var rowErr *RowError
+	_, err = sliceToStruct.ToStruct([]string{"", "1"})
+	if !errors.As(err, &headerErr) || errors.As(err, &rowErr) {
+		t.Errorf("ToStruct should return SetFieldNames error, %v", err)
+	}
+
+	err = sliceToStruct.SetFieldNames([]string{"name", "notes", "id"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	_, err = sliceToStruct.ToStruct([]string{"name", "notes", ""})
+	if !errors.Is(err, ErrRequired) || !errors.As(err, &fieldErr) || fieldErr.Field != "ID" || fieldErr.SliceIndex != 2 {
+		t.Errorf("should has ErrRequired, %v", err)
+	}
+
+	sliceToStruct2 := New[TRequiredDefault](Params{})
+	if !errors.Is(sliceToStruct2.Err(), ErrInvalidTag) {
+		t.Errorf("should has error, %v", sliceToStruct2.Err())
+	}
+}
+
+func TestValidateHeader(t *testing.T) {
+	sliceToStruct := New[TRequired](Params{
+		NotCaseSensitive: true,
+	})
+	report, err := sliceToStruct.ValidateHeader([]string{"ID", "extra", "notes", "Id", "extra"})
+	if !errors.Is(err, ErrRequired) {
+		t.Errorf("should has ErrRequired, %v", err)
+	}
+	if report.OK() ||
+		fmt.Sprint(report.Mapped) != fmt.Sprint([]HeaderColumn{{Field: "ID", Column: "id", Index: 3, Required: true}, {Field: "Notes", Column: "notes", Index: 2}}) ||
+		fmt.Sprint(report.MissingFields) != fmt.Sprint([]HeaderColumn{{Field: "Name", Column: "name", Index: -1, Required: true}}) ||
+		fmt.Sprint(report.UnknownColumns) != fmt.Sprint([]HeaderColumn{{Column: "extra", Index: 4}}) ||
+		fmt.Sprint(report.Duplicates) != fmt.Sprint([]HeaderColumn{{Column: "extra", Index: 4}}) ||
+		fmt.Sprint(report.CaseCollisions) != fmt.Sprint([]HeaderColumn{{Column: "Id", Index: 3}}) {
+		t.Errorf("wrong report %+v", report)
+	}
+	if sliceToStruct.Err() != nil || len(sliceToStruct.fieldNames) != 0 {
+		t.Error("ValidateHeader should not change fieldNames")
+	}
+
+	report, err = sliceToStruct.ValidateHeader([]string{"name", "ID", "notes"})
+	if err != nil || !report.OK() || len(report.Mapped) != 3 {
+		t.Errorf("wrong report %+v, %v", report, err)
+	}
+}
+
+type TRest struct {
+	ID   int64             `ss:"id"`
+	Name string            `ss:"name"`
+	Rest map[string]string `ss:",rest"`
+}
+
+func TestStrictness(t *testing.T) {
+	header := []string{"id", "Extra", "name"}
+
+	sliceToStruct := New[T3](Params{
+		FieldNames: header,
+		Strictness: StrictnessStrict,
+	})
+	var headerErr *HeaderError
+	if !errors.Is(sliceToStruct.Err(), ErrUnknownColumn) || !errors.As(sliceToStruct.Err(), &headerErr) ||
+		len(headerErr.Errors) != 2 || headerErr.Errors[0].Column != "Extra" || headerErr.Errors[1].Column != "name" {
+		t.Errorf("should has ErrUnknownColumn, %v", sliceToStruct.Err())
+	}
+	sliceToStruct = New[T3](Params{
+		Strictness: StrictnessStrict,
+	})
+	_, err := sliceToStruct.ToStruct([]string{"1", "2"})
+	if err != nil {
+		t.Errorf("%+v", err)
+	}
+	_, err = sliceToStruct.ToStruct([]string{"1", "2", "3"})
+	if !errors.Is(err, ErrUnknownColumn) {
+		t.Errorf("should has ErrUnknownColumn, %v", err)
+	}
+
+	sliceToStruct2 := New[TRest](Params{
+		FieldNames: header,
+		Strictness: StrictnessLenient,
+	})
+	res, err := sliceToStruct2.ToStruct([]string{"1", "x", "name", "y"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.ID != 1 || res.Name != "name" || fmt.Sprint(res.Rest) != "map[3:y Extra:x]" {
+		t.Errorf("wrong result %+v", res)
+	}
+	_, err = New[TRest](Params{
+		FieldNames: header,
+	}).ToStruct([]string{"1", "x", "name", "y"})
+	if err == nil {
+		t.Error("should has error, count items greater then fieldNames")
+	}
+
+	res, err = New[TRest](Params{
+		NotCaseSensitive: true,
+		FieldNames:       []string{"ID", "Extra", "NAME"},
+		Strictness:       StrictnessCollect,
+	}).ToStruct([]string{"1", "x", "name"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(res.Rest) != "map[Extra:x]" {
+		t.Errorf("wrong result %+v", res)
+	}
+
+	// positional, rest field does not take item
+	for _, strictness := range []Strictness{StrictnessLenient, StrictnessCollect} {
+		res, err = New[TRest](Params{
+			Strictness: strictness,
+		}).ToStruct([]string{"1", "name", "x", "y"})
+		if err != nil {
+			t.Errorf("%+v", err)
+			return
+		}
+		if res.ID != 1 || res.Name != "name" || fmt.Sprint(res.Rest) != "map[2:x 3:y]" {
+			t.Errorf("wrong result %v, %+v", strictness, res)
+		}
+	}
+	_, err = New[TRest](Params{
+		Strictness: StrictnessStrict,
+	}).ToStruct([]string{"1", "name", "x"})
+	if !errors.Is(err, ErrUnknownColumn) {
+		t.Errorf("should has ErrUnknownColumn, %v", err)
+	}
+
+	sliceToStruct3 := New[T3](Params{
+		Strictness: StrictnessCollect,
+	})
+	if !errors.Is(sliceToStruct3.Err(), ErrInvalidTag) {
+		t.Errorf("should has error, StrictnessCollect requires rest field, %v", sliceToStruct3.Err())
+	}
+}
+
+type TRestCells struct {
+	ID   int64  `ss:"id"`
+	Rest []Cell `ss:",rest"`
+	Name string `ss:"name"`
+}
+
+func TestRest(t *testing.T) {
+	header := []string{"Supplier Code", "ID", "name", "Comment"}
+	row := []string{"S1", "1", "name", "fragile", "extra"}
+
+	sliceToStruct := New[TRest](Params{
+		NotCaseSensitive: true,
+		FieldNames:       header,
+		Strictness:       StrictnessLenient,
+	})
+	res, err := sliceToStruct.ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(res.Rest) != "map[4:extra Comment:fragile Supplier Code:S1]" {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint(row) {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	sliceToStruct2 := New[TRestCells](Params{
+		NotCaseSensitive: true,
+		FieldNames:       header,
+		Strictness:       StrictnessCollect,
+	})
+	res2, err := sliceToStruct2.ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(res2.Rest) != fmt.Sprint([]Cell{{0, "Supplier Code", "S1"}, {3, "Comment", "fragile"}, {4, "", "extra"}}) {
+		t.Errorf("wrong result %+v", res2)
+	}
+	slice, err = sliceToStruct2.ToSlice(res2)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint(row) {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	// rest field can not override mapped field
+	res2.Rest = append(res2.Rest, Cell{Index: 1, Value: "2"})
+	slice, err = sliceToStruct2.ToSlice(res2)
+	if err != nil || slice[1] != "1" {
+		t.Errorf("wrong result %v, %v", slice, err)
+	}
+
+	// positional
+	res2, err = New[TRestCells](Params{
+		Strictness: StrictnessLenient,
+	}).ToStruct([]string{"1", "name", "a", "b"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res2.ID != 1 || res2.Name != "name" || fmt.Sprint(res2.Rest) != fmt.Sprint([]Cell{{2, "", "a"}, {3, "", "b"}}) {
+		t.Errorf("wrong result %+v", res2)
+	}
+	slice, err = New[TRestCells](Params{
+		Strictness: StrictnessLenient,
+	}).ToSlice(res2)
+	if err != nil || fmt.Sprint(slice) != fmt.Sprint([]string{"1", "name", "a", "b"}) {
+		t.Errorf("wrong result %v, %v", slice, err)
+	}
+}
+
+type TBase struct {
+	ID int64 `ss:"id"`
+}
+
+type TAddress struct {
+	City   string `ss:"city"`
+	Street string `ss:"street"`
+}
+
+type TNested struct {
+	TBase
+	Name     string    `ss:"name"`
+	Address  TAddress  `ss:"address"`
+	Billing  *TAddress `ss:",prefix=billing_"`
+	Shipping *TAddress `ss:",inline"`
+}
+
+type TNestedRecursive struct {
+	Name string
+	Next *TNestedRecursive
+}
+
+func TestNested(t *testing.T) {
+	header := []string{"id", "name", "address.city", "address.street", "billing_city", "billing_street", "city", "street"}
+	sliceToStruct := New[TNested](Params{
+		FieldNames: header,
+		Strictness: StrictnessLenient,
+	})
+	res, err := sliceToStruct.ToStruct([]string{"1", "name", "Moscow", "Tverskaya", "", "", "", ""})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.ID != 1 || res.Name != "name" || res.Address.City != "Moscow" || res.Address.Street != "Tverskaya" {
+		t.Errorf("wrong result %+v", res)
+	}
+	// pointer is allocated only if nested field is set
+	if res.Billing != nil || res.Shipping != nil {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint([]string{"1", "name", "Moscow", "Tverskaya", "", "", "", ""}) {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	res, err = sliceToStruct.ToStruct([]string{"1", "name", "", "", "Kazan", "Baumana", "", ""})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Billing == nil || res.Billing.City != "Kazan" || res.Billing.Street != "Baumana" {
+		t.Errorf("wrong result %+v", res)
+	}
+
+	report, err := sliceToStruct.ValidateHeader(header)
+	if err != nil || len(report.Mapped) != 8 || report.Mapped[2].Field != "Address.City" {
+		t.Errorf("wrong result %+v, %v", report, err)
+	}
+
+	// positional, depth-first
+	res, err = New[TNested](Params{}).ToStruct([]string{"1", "name", "Moscow", "Tverskaya", "Kazan", "Baumana", "Omsk", "Lenina"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.ID != 1 || res.Address.City != "Moscow" || res.Billing.Street != "Baumana" || res.Shipping.City != "Omsk" || res.Shipping.Street != "Lenina" {
+		t.Errorf("wrong result %+v", res)
+	}
+
+	_, err = New[TNestedRecursive](Params{}).ToStruct([]string{"name"})
+	if !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("wrong result %v", err)
+	}
+}
+
+type TSlice struct {
+	Colors []string        `ss:"colors,sep=;"`
+	IDs    []int64         `ss:"ids"`
+	Dates  []time.Time     `ss:"dates,sep=|,layout=2006-01-02"`
+	Nulls  []sql.NullInt64 `ss:"nulls"`
+	Codes  []Code          `ss:"codes,omitempty"`
+}
+
+func TestSlice(t *testing.T) {
+	sliceToStruct := New[TSlice](Params{
+		FieldNames: []string{"colors", "ids", "dates", "nulls", "codes"},
+	})
+	res, err := sliceToStruct.ToStruct([]string{"red;green; blue", "1, 2, 3", "2020-01-02 | 2021-03-04", "1,,3", ""})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(res.Colors) != "[red green blue]" || fmt.Sprint(res.IDs) != "[1 2 3]" {
+		t.Errorf("wrong result %+v", res)
+	}
+	if len(res.Dates) != 2 || !res.Dates[1].Equal(time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("wrong result %+v", res.Dates)
+	}
+	if fmt.Sprint(res.Nulls) != fmt.Sprint([]sql.NullInt64{{Int64: 1, Valid: true}, {}, {Int64: 3, Valid: true}}) || res.Codes != nil {
+		t.Errorf("wrong result %+v", res)
+	}
+
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint([]string{"red;green;blue", "1,2,3", "2020-01-02|2021-03-04", "1,,3", ""}) {
+		t.Errorf("wrong result %q", slice)
+	}
+
+	_, err = sliceToStruct.ToStruct([]string{"", "1,x", "", "", ""})
+	if !errors.Is(err, ErrParse) || !strings.Contains(err.Error(), "element = 1") {
+		t.Errorf("wrong result %v", err)
+	}
+}
+
+type TColumns struct {
+	Name     string      `ss:"name"`
+	Months   [12]float64 `ss:"months,range=1:12"`
+	Quarters []int64     `ss:"quarters,re=^Q[1-4]$"`
+	Total    float64     `ss:"total"`
+}
+
+func TestColumns(t *testing.T) {
+	header := []string{"name", "Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec", "Q1", "Q2", "Q3", "Q4", "total"}
+	row := []string{"a", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12.5", "10", "20", "30", "40", "78.5"}
+	sliceToStruct := New[TColumns](Params{
+		FieldNames: header,
+		Strictness: StrictnessStrict,
+	})
+	res, err := sliceToStruct.ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Name != "a" || res.Months[0] != 1 || res.Months[11] != 12.5 || fmt.Sprint(res.Quarters) != "[10 20 30 40]" || res.Total != 78.5 {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint(row) {
+		t.Errorf("wrong result %v", slice)
+	}
+
+	report, err := sliceToStruct.ValidateHeader(header)
+	if err != nil || !report.OK() || len(report.Mapped) != len(header) {
+		t.Errorf("wrong result %+v, %v", report, err)
+	}
+
+	row[14] = "x"
+	_, err = sliceToStruct.ToStruct(row)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.SliceIndex != 14 || !errors.Is(err, ErrParse) {
+		t.Errorf("wrong result %v", err)
+	}
+
+	// re does not have names to match without fieldNames
+	if !errors.Is(New[TColumns](Params{}).Err(), ErrInvalidTag) {
+		t.Error("wrong result")
+	}
+
+	// positional, total follows range
+	type TColumnsPositional struct {
+		Name   string      `ss:"name"`
+		Months [12]float64 `ss:"months,range=1:12"`
+		Total  float64     `ss:"total"`
+	}
+	positional, err := New[TColumnsPositional](Params{}).ToStruct([]string{"b", "1", "", "3", "", "", "", "", "", "", "", "", "12", "99"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if positional.Name != "b" || positional.Months[0] != 1 || positional.Months[1] != 0 || positional.Months[11] != 12 || positional.Total != 99 {
+		t.Errorf("wrong result %+v", positional)
+	}
+
+	type TColumnsLonger struct {
+		Months [2]float64 `ss:"months,range=1:12"`
+	}
+	if !errors.Is(New[TColumnsLonger](Params{}).Err(), ErrInvalidTag) {
+		t.Error("wrong result")
+	}
+	type TColumnsShorter struct {
+		Months [12]float64 `ss:"months,range=5:15"`
+	}
+	if !errors.Is(New[TColumnsShorter](Params{}).Err(), ErrInvalidTag) {
+		t.Error("wrong result")
+	}
+
+	// range of request example, Jan..Dec are columns 5 to 16
+	type TColumnsMonths struct {
+		Months [12]float64 `ss:"months,range=5:16"`
+		Total  float64     `ss:"total"`
+	}
+	row = make([]string, 18)
+	for i := range row {
+		row[i] = strconv.Itoa(i)
+	}
+	months, err := New[TColumnsMonths](Params{
+		Strictness: StrictnessLenient,
+	}).ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if months.Months[0] != 5 || months.Months[11] != 16 || months.Total != 17 {
+		t.Errorf("wrong result %+v", months)
+	}
+}
+
+type TNull struct {
+	ID     *int64          `ss:"id"`
+	Count  sql.NullInt64   `ss:"count"`
+	Price  sql.NullFloat64 `ss:"price,null=-|—"`
+	Name   string          `ss:"name,omitempty"`
+	Code   string          `ss:"code"`
+	Months []sql.NullInt64 `ss:"months,range=5:6"`
+}
+
+func TestNullTokens(t *testing.T) {
+	sliceToStruct := New[TNull](Params{
+		FieldNames: []string{"id", "count", "price", "name", "code", "m1", "m2"},
+		NullTokens: []string{"NULL", "N/A", "#N/A"},
+		NullOutput: "NULL",
+	})
+	res, err := sliceToStruct.ToStruct([]string{"null", "N/A", "—", "#N/A", "N/A", "1", "NULL"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.ID != nil || res.Count.Valid || res.Price.Valid || res.Name != "" || res.Code != "N/A" {
+		t.Errorf("wrong result %+v", res)
+	}
+	if fmt.Sprint(res.Months) != fmt.Sprint([]sql.NullInt64{{Int64: 1, Valid: true}, {}}) {
+		t.Errorf("wrong result %+v", res.Months)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint([]string{"NULL", "NULL", "-", "", "N/A", "1", "NULL"}) {
+		t.Errorf("wrong result %q", slice)
+	}
+
+	// field tokens override Params.NullTokens
+	_, err = sliceToStruct.ToStruct([]string{"", "", "NULL", "", "", "", ""})
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("wrong result %v", err)
+	}
+}
+
+type TNumberFormat struct {
+	Amount  float64         `ss:"amount"`
+	Count   int64           `ss:"count"`
+	Null    sql.NullFloat64 `ss:"null"`
+	Swiss   uint32          `ss:"swiss,group=apostrophe"`
+	English float64         `ss:"english,decimal=dot,group=comma"`
+}
+
+func TestNumberFormat(t *testing.T) {
+	sliceToStruct := New[TNumberFormat](Params{
+		NumberFormat: NumberFormat{
+			Decimal: "comma",
+			Group:   "space",
+		},
+	})
+	res, err := sliceToStruct.ToStruct([]string{"1 234 567,89", "-1 234", "12 345,5", "1'000'000", "1,234,567.89"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Amount != 1234567.89 || res.Count != -1234 || res.Null.Float64 != 12345.5 || res.Swiss != 1000000 || res.English != 1234567.89 {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint([]string{"1 234 567,89", "-1 234", "12 345,5", "1'000'000", "1,234,567.89"}) {
+		t.Errorf("wrong result %q", slice)
+	}
+
+	for _, row := range [][]string{
+		{"1 2 3", "0", "", "0", "0"},
+		{"0", "12 34", "", "0", "0"},
+		{"0", "0", "1,2,3", "0", "0"},
+		{"0", "0", "", "0", "1,2,3"},
+		{"0", "0", "", "0", "1234,567.8"},
+	} {
+		_, err = sliceToStruct.ToStruct(row)
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) || !errors.Is(err, ErrParse) || fieldErr.Value == "0" {
+			t.Errorf("wrong result %q, %v", row, err)
+		}
+	}
+
+	type TNumberFormatInvalid struct {
+		Amount float64 `ss:"amount,decimal=comma,group=comma"`
+	}
+	if !errors.Is(New[TNumberFormatInvalid](Params{}).Err(), ErrInvalidTag) {
+		t.Error("wrong result")
+	}
+}
+
+type TMoney struct {
+	Price    float64         `ss:"price,currency=$|₽|USD,currency_field=Currency,group=comma,parens"`
+	Currency string          `ss:"-"`
+	Rub      int64           `ss:"rub,currency=₽,currency_required,currency_pos=suffix,decimal=comma,group=space"`
+	Rate     float64         `ss:"rate,percent"`
+	Null     sql.NullFloat64 `ss:"null,percent,parens"`
+}
+
+func TestMoney(t *testing.T) {
+	sliceToStruct := New[TMoney](Params{})
+	res, err := sliceToStruct.ToStruct([]string{"($1,234.50)", "-", "1 234 ₽", "15%", "(12.5%)"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Price != -1234.5 || res.Currency != "$" || res.Rub != 1234 || res.Rate != 0.15 || res.Null.Float64 != -0.125 {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint([]string{"($1,234.5)", "", "1 234 ₽", "15%", "(12.5%)"}) {
+		t.Errorf("wrong result %q", slice)
+	}
+
+	res, err = sliceToStruct.ToStruct([]string{"1,234 USD", "", "-5 ₽", "20%", ""})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Price != 1234 || res.Currency != "USD" || res.Rub != -5 || res.Rate != 0.2 {
+		t.Errorf("wrong result %+v", res)
+	}
+	// currency symbol is rendered on side where it was found
+	slice, err = sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint([]string{"1,234 USD", "", "-5 ₽", "20%", ""}) {
+		t.Errorf("wrong result %q", slice)
+	}
+
+	// currency is required
+	_, err = sliceToStruct.ToStruct([]string{"1", "", "5", "0", ""})
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("wrong result %v", err)
+	}
+
+	// percent of exponent or empty number and number without percent sign are error
+	for _, rate := range []string{"1e3%", "1E3%", "%", "-%", "(%)", "15", "0.2"} {
+		_, err = sliceToStruct.ToStruct([]string{"1", "", "5 ₽", rate, ""})
+		if !errors.Is(err, ErrParse) {
+			t.Errorf("wrong result %q, %v", rate, err)
+		}
+	}
+
+	type TMoneyInvalid struct {
+		Rate int64 `ss:"rate,percent"`
+	}
+	if !errors.Is(New[TMoneyInvalid](Params{}).Err(), ErrInvalidTag) {
+		t.Error("wrong result")
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	d, err := ParseDecimal("-1234.505")
+	if err != nil || d.String() != "-1234.505" || d.Scale() != 3 {
+		t.Errorf("wrong result %v, %v", d, err)
+	}
+	for _, c := range []struct {
+		value string
+		mode  RoundingMode
+		res   string
+	}{
+		{"2.5", RoundHalfUp, "3"},
+		{"-2.5", RoundHalfUp, "-3"},
+		{"2.5", RoundHalfEven, "2"},
+		{"3.5", RoundHalfEven, "4"},
+		{"2.5", RoundHalfDown, "2"},
+		{"2.51", RoundHalfDown, "3"},
+		{"-2.9", RoundDown, "-2"},
+		{"-2.1", RoundUp, "-3"},
+		{"-2.1", RoundFloor, "-3"},
+		{"2.1", RoundCeiling, "3"},
+	} {
+		d, err := ParseDecimal(c.value)
+		if err != nil || d.Round(0, c.mode).String() != c.res {
+			t.Errorf("wrong result %s, %v, %v", c.value, c.mode, d.Round(0, c.mode))
+		}
+	}
+	if NewDecimal(5, 3).String() != "0.005" || NewDecimal(5, 1).Round(3, RoundHalfUp).String() != "0.500" {
+		t.Error("wrong result")
+	}
+	a, _ := ParseDecimal("0.1")
+	b, _ := ParseDecimal("0.2")
+	if a.Add(b).String() != "0.3" || a.Sub(b).Cmp(NewDecimal(-1, 1)) != 0 {
+		t.Errorf("wrong result %v", a.Add(b))
+	}
+	for _, value := range []string{"", "-", "1.2.3", "1e3", "abc"} {
+		if _, err := ParseDecimal(value); err == nil {
+			t.Errorf("wrong result %q", value)
+		}
+	}
+
+	var scanned Decimal
+	if err := scanned.Scan([]byte("10.50")); err != nil || scanned.String() != "10.50" {
+		t.Errorf("wrong result %v, %v", scanned, err)
+	}
+	if v, err := scanned.Value(); err != nil || v != "10.50" {
+		t.Errorf("wrong result %v, %v", v, err)
+	}
+}
+
+type TDecimalField struct {
+	Total  Decimal  `ss:"total,decimal=comma,group=space"`
+	Price  Decimal  `ss:"price,scale=2,round=half_even"`
+	Cents  int64    `ss:"cents,scale=2,currency=$,parens"`
+	Rate   Decimal  `ss:"rate,percent"`
+	Nil    *Decimal `ss:"nil"`
+	Strict int32    `ss:"strict,scale=2"`
+}
+
+func TestDecimalField(t *testing.T) {
+	sliceToStruct := New[TDecimalField](Params{})
+	res, err := sliceToStruct.ToStruct([]string{"1 234 567,891", "10.125", "($12.34)", "7.25%", "", "1.5"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Total.String() != "1234567.891" || res.Price.String() != "10.12" || res.Cents != -1234 || res.Rate.String() != "0.0725" || res.Nil != nil || res.Strict != 150 {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint([]string{"1 234 567,891", "10.12", "(12.34)", "7.25%", "", "1.50"}) {
+		t.Errorf("wrong result %q", slice)
+	}
+
+	// empty cell and null token are zero Decimal
+	res, err = New[TDecimalField](Params{NullTokens: []string{"NULL"}}).ToStruct([]string{"", "NULL", "0", "0%", "NULL", "0"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Total.String() != "0" || res.Price.String() != "0" || res.Nil != nil {
+		t.Errorf("wrong result %+v", res)
+	}
+
+	// without round extra digits are error
+	_, err = sliceToStruct.ToStruct([]string{"0", "0", "0", "0%", "", "1.505"})
+	if !errors.Is(err, ErrParse) {
+		t.Errorf("wrong result %v", err)
+	}
+	// overflow of minor units
+	_, err = sliceToStruct.ToStruct([]string{"0", "0", "0", "0%", "", "30000000"})
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("wrong result %v", err)
+	}
+
+	type TDecimalInvalid struct {
+		Amount float64 `ss:"amount,scale=2"`
+	}
+	if !errors.Is(New[TDecimalInvalid](Params{}).Err(), ErrInvalidTag) {
+		t.Error("wrong result")
+	}
+}
+
+type TTimeLayouts struct {
+	Date    time.Time    `ss:"date,layout=02.01.2006|2006-01-02|02.01.2006 15:04|2006-01-02T15:04:05Z07:00"`
+	Default *time.Time   `ss:"default"`
+	Null    sql.NullTime `ss:"null"`
+}
+
+func TestTimeLayouts(t *testing.T) {
+	sliceToStruct := New[TTimeLayouts](Params{
+		TimeLayouts: []string{"2006-01-02", "02.01.2006"},
+	})
+	for _, c := range []struct {
+		item string
+		res  time.Time
+	}{
+		{"03.02.2021", time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{"2021-02-03", time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{"03.02.2021 10:30", time.Date(2021, 2, 3, 10, 30, 0, 0, time.UTC)},
+		{"2021-02-03T10:30:00Z", time.Date(2021, 2, 3, 10, 30, 0, 0, time.UTC)},
+	} {
+		res, err := sliceToStruct.ToStruct([]string{c.item, "04.02.2021", "2021-02-05"})
+		if err != nil {
+			t.Errorf("%+v", err)
+			continue
+		}
+		if !res.Date.Equal(c.res) || !res.Default.Equal(time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC)) || !res.Null.Time.Equal(time.Date(2021, 2, 5, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("wrong result %s, %+v", c.item, res)
+		}
+	}
+
+	res, err := sliceToStruct.ToStruct([]string{"2021-02-03", "2021-02-04", "2021-02-05"})
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint([]string{"03.02.2021", "2021-02-04", "2021-02-05"}) {
+		t.Errorf("wrong result %q", slice)
+	}
+
+	_, err = sliceToStruct.ToStruct([]string{"2021/02/03", "", ""})
+	if !errors.Is(err, ErrParse) || !strings.Contains(err.Error(), "02.01.2006 15:04") || !strings.Contains(err.Error(), "2006-01-02T15:04:05Z07:00") {
+		t.Errorf("wrong result %v", err)
+	}
+}
+
+type TParamsAfterNew struct {
+	Float float64   `ss:"float"`
+	Bool  bool      `ss:"bool"`
+	Date  time.Time `ss:"date"`
+	Int   int64     `ss:"int"`
+}
+
+func TestParamsAfterNew(t *testing.T) {
+	sliceToStruct := New[TParamsAfterNew](Params{})
+	sliceToStruct.ReplaceCommaToDot = true
+	sliceToStruct.TrueTokens = []string{"yes"}
+	sliceToStruct.FalseTokens = []string{"no"}
+	sliceToStruct.TimeLayouts = []string{"2006-01-02"}
+	sliceToStruct.AllowIntBasePrefix = true
+	row := []string{"1,5", "yes", "2021-02-03", "0x10"}
+	res, err := sliceToStruct.ToStruct(row)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if res.Float != 1.5 || !res.Bool || !res.Date.Equal(time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)) || res.Int != 16 {
+		t.Errorf("wrong result %+v", res)
+	}
+	slice, err := sliceToStruct.ToSlice(res)
+	if err != nil {
+		t.Errorf("%+v", err)
+		return
+	}
+	if fmt.Sprint(slice) != fmt.Sprint([]string{"1,5", "yes", "2021-02-03", "16"}) {
+		t.Errorf("wrong result %q", slice)
+	}
+}
@@ -3,6 +3,7 @@ package slicetostruct
 import (
 	"database/sql"
 	"reflect"
+	"strconv"
 
 	"github.com/go-faster/errors"
 )
@@ -22,3 +23,14 @@ func (c *ConvertSqlNullInt64) Set(value *ConvertValueParams) error {
 	value.ReflectValue.Set(reflect.ValueOf(c.Value))
 	return nil
 }
+
+func (c *ConvertSqlNullInt64) Format(value *FormatValueParams) (string, error) {
+	v, ok := value.ReflectValue.Interface().(sql.NullInt64)
+	if !ok {
+		return "", errors.Errorf("value is not sql.NullInt64, %s", value.FieltType)
+	}
+	if !v.Valid {
+		return "", nil
+	}
+	return strconv.FormatInt(v.Int64, 10), nil
+}
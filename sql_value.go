@@ -4,8 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
-	"strings"
-	"time"
+	"strconv"
 
 	"github.com/go-faster/errors"
 )
@@ -23,18 +22,23 @@ func (c *ConvertSqlValue) Set(value *ConvertValueParams) error {
 	var err error
 	switch value.FieltType {
 	case "sql.NullInt64":
+		item, err := normalizeNumber(value.fieldOptions(c.params), value.Items[value.Index])
+		if err != nil {
+			return err
+		}
 		v := sql.NullInt64{}
-		err = v.Scan(value.Items[value.Index])
+		err = v.Scan(item)
 		if err != nil {
 			return errors.Wrap(err, "cant c.Value.Scan")
 		}
 		value.ReflectValue.Set(reflect.ValueOf(v))
 	case "sql.NullFloat64":
-		if c.params.ReplaceCommaToDot {
-			value.Items[value.Index] = strings.Replace(value.Items[value.Index], ",", ".", 1)
+		item, err := normalizeNumber(value.fieldOptions(c.params), value.Items[value.Index])
+		if err != nil {
+			return err
 		}
 		v := sql.NullFloat64{}
-		err = v.Scan(value.Items[value.Index])
+		err = v.Scan(item)
 		if err != nil {
 			return errors.Wrap(err, "cant c.Value.Scan")
 		}
@@ -47,41 +51,51 @@ func (c *ConvertSqlValue) Set(value *ConvertValueParams) error {
 		}
 		value.ReflectValue.Set(reflect.ValueOf(v))
 	case "sql.NullInt32":
+		item, err := normalizeNumber(value.fieldOptions(c.params), value.Items[value.Index])
+		if err != nil {
+			return err
+		}
 		v := sql.NullInt32{}
-		err = v.Scan(value.Items[value.Index])
+		err = v.Scan(item)
 		if err != nil {
 			return errors.Wrap(err, "cant c.Value.Scan")
 		}
 		value.ReflectValue.Set(reflect.ValueOf(v))
 	case "sql.NullInt16":
+		item, err := normalizeNumber(value.fieldOptions(c.params), value.Items[value.Index])
+		if err != nil {
+			return err
+		}
 		v := sql.NullInt16{}
-		err = v.Scan(value.Items[value.Index])
+		err = v.Scan(item)
 		if err != nil {
 			return errors.Wrap(err, "cant c.Value.Scan")
 		}
 		value.ReflectValue.Set(reflect.ValueOf(v))
 	case "sql.NullByte":
+		item, err := normalizeNumber(value.fieldOptions(c.params), value.Items[value.Index])
+		if err != nil {
+			return err
+		}
 		v := sql.NullByte{}
-		err = v.Scan(value.Items[value.Index])
+		err = v.Scan(item)
 		if err != nil {
 			return errors.Wrap(err, "cant c.Value.Scan")
 		}
 		value.ReflectValue.Set(reflect.ValueOf(v))
 	case "sql.NullBool":
-		v := sql.NullBool{}
-		err = v.Scan(value.Items[value.Index])
+		b, err := parseBool(value.fieldOptions(c.params), value.Items[value.Index])
 		if err != nil {
-			return errors.Wrap(err, "cant c.Value.Scan")
+			return err
 		}
+		v := sql.NullBool{}
+		v.Bool = b
+		v.Valid = true
 		value.ReflectValue.Set(reflect.ValueOf(v))
 	case "sql.NullTime":
-		timeLayout := defaultTimeLayout
-		if len(value.Tags) > 2 {
-			timeLayout = value.Tags[2]
-		}
-		t, err := time.Parse(timeLayout, value.Items[value.Index])
+		t, err := parseTime(value.fieldOptions(c.params), value.Items[value.Index])
 		if err != nil {
-			return errors.Wrap(err, "cant time.Parse")
+			return err
 		}
 
 		v := sql.NullTime{}
@@ -94,3 +108,50 @@ func (c *ConvertSqlValue) Set(value *ConvertValueParams) error {
 
 	return nil
 }
+
+func (c *ConvertSqlValue) Format(value *FormatValueParams) (string, error) {
+	switch v := value.ReflectValue.Interface().(type) {
+	case sql.NullInt64:
+		if !v.Valid {
+			return "", nil
+		}
+		return formatNumber(value.fieldOptions(c.params), strconv.FormatInt(v.Int64, 10)), nil
+	case sql.NullFloat64:
+		if !v.Valid {
+			return "", nil
+		}
+		return formatFloat(value.fieldOptions(c.params), v.Float64, 64), nil
+	case sql.NullString:
+		if !v.Valid {
+			return "", nil
+		}
+		return v.String, nil
+	case sql.NullInt32:
+		if !v.Valid {
+			return "", nil
+		}
+		return formatNumber(value.fieldOptions(c.params), strconv.FormatInt(int64(v.Int32), 10)), nil
+	case sql.NullInt16:
+		if !v.Valid {
+			return "", nil
+		}
+		return formatNumber(value.fieldOptions(c.params), strconv.FormatInt(int64(v.Int16), 10)), nil
+	case sql.NullByte:
+		if !v.Valid {
+			return "", nil
+		}
+		return formatNumber(value.fieldOptions(c.params), strconv.FormatUint(uint64(v.Byte), 10)), nil
+	case sql.NullBool:
+		if !v.Valid {
+			return "", nil
+		}
+		return formatBool(value.fieldOptions(c.params), v.Bool), nil
+	case sql.NullTime:
+		if !v.Valid {
+			return "", nil
+		}
+		return formatTime(value.fieldOptions(c.params), v.Time)
+	default:
+		return "", errors.New(fmt.Sprintf("field type unknown = %s", value.FieltType))
+	}
+}
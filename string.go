@@ -0,0 +1,25 @@
+package slicetostruct
+
+import (
+	"reflect"
+)
+
+// ConvertString converts string, *string and types with underlying string kind.
+type ConvertString struct {
+}
+
+func (c *ConvertString) Set(value *ConvertValueParams) error {
+	elem(value.ReflectValue).SetString(value.Items[value.Index])
+	return nil
+}
+
+func (c *ConvertString) Format(value *FormatValueParams) (string, error) {
+	v := *value.ReflectValue
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	return v.String(), nil
+}
@@ -0,0 +1,173 @@
+package slicetostruct
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-faster/errors"
+)
+
+var ErrInvalidTag = fmt.Errorf("invalid ss tag")
+
+// tag flags and key=value options, anything else is ErrInvalidTag
+var tagFlags = map[string]bool{
+	"omitempty":         true,
+	"required":          true,
+	"rest":              true,
+	"inline":            true,
+	"parens":            true,
+	"percent":           true,
+	"currency_required": true,
+}
+var tagKeys = map[string]bool{
+	"layout":         true,
+	"default":        true,
+	"tz":             true,
+	"conv":           true,
+	"true":           true,
+	"false":          true,
+	"prefix":         true,
+	"sep":            true,
+	"range":          true,
+	"re":             true,
+	"null":           true,
+	"decimal":        true,
+	"group":          true,
+	"currency":       true,
+	"currency_field": true,
+	"currency_pos":   true,
+	"scale":          true,
+	"round":          true,
+}
+
+type tagOptions struct {
+	name      string
+	omitEmpty bool
+	flags     map[string]bool
+	options   map[string]string
+	// normalized tags for ConvertValueParams.Tags: name, omitempty or "", layout, other flags and key=value options
+	tags []string
+}
+
+func (t *tagOptions) option(key string) (string, bool) {
+	v, ok := t.options[key]
+	return v, ok
+}
+
+// parseTag parses ss tag, see package doc, grammar is
+//
+//	tag    = name { "," option }
+//	option = "" | flag | key "=" value
+//
+// third element without = is layout of legacy positional form.
+func parseTag(tagStr string) (tagOptions, error) {
+	elements, err := splitTag(tagStr)
+	if err != nil {
+		return tagOptions{}, err
+	}
+
+	res := tagOptions{
+		name:    elements[0],
+		flags:   make(map[string]bool),
+		options: make(map[string]string),
+	}
+	keys := make([]string, 0, len(elements))
+	flags := make([]string, 0, len(elements))
+	for i := 1; i < len(elements); i++ {
+		element := elements[i]
+		if element == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(element, "=")
+		if !ok {
+			if tagFlags[element] {
+				if !res.flags[element] && element != "omitempty" {
+					flags = append(flags, element)
+				}
+				res.flags[element] = true
+				continue
+			}
+			if i != 2 {
+				return tagOptions{}, errors.Wrapf(ErrInvalidTag, "unknown flag %q", element)
+			}
+			key, value = "layout", element
+		}
+		if key == "" {
+			return tagOptions{}, errors.Wrapf(ErrInvalidTag, "empty key of option %q", element)
+		}
+		if !tagKeys[key] {
+			return tagOptions{}, errors.Wrapf(ErrInvalidTag, "unknown option %q", key)
+		}
+		if _, ok := res.options[key]; ok {
+			return tagOptions{}, errors.Wrapf(ErrInvalidTag, "duplicate option %q", key)
+		}
+		res.options[key] = value
+		keys = append(keys, key)
+	}
+
+	res.omitEmpty = res.flags["omitempty"]
+	res.tags = make([]string, 3, 3+len(flags)+len(keys))
+	res.tags[0] = res.name
+	if res.omitEmpty {
+		res.tags[1] = "omitempty"
+	}
+	res.tags[2] = res.options["layout"]
+	res.tags = append(res.tags, flags...)
+	for _, key := range keys {
+		if key != "layout" {
+			res.tags = append(res.tags, key+"="+res.options[key])
+		}
+	}
+	return res, nil
+}
+
+// splitTag splits tag by comma, considering quotes and # escape.
+func splitTag(tagStr string) ([]string, error) {
+	var res []string
+	var cur strings.Builder
+	inQuote := false
+	// quote opens only at start of element or start of value
+	canQuote := true
+	hasKey := false
+	for i := 0; i < len(tagStr); i++ {
+		ch := tagStr[i]
+		switch {
+		case inQuote && ch == '\'':
+			if i+1 < len(tagStr) && tagStr[i+1] == '\'' {
+				cur.WriteByte('\'')
+				i++
+				continue
+			}
+			inQuote = false
+			canQuote = false
+		case inQuote:
+			cur.WriteByte(ch)
+		case ch == '\'' && canQuote:
+			inQuote = true
+		case ch == ',' && !hasKey && i > 0 && tagStr[i-1] == '#':
+			// # escapes comma of name and positional element only, option value may end with #
+			element := cur.String()
+			cur.Reset()
+			cur.WriteString(element[:len(element)-1])
+			cur.WriteByte(',')
+			canQuote = false
+		case ch == ',':
+			res = append(res, cur.String())
+			cur.Reset()
+			canQuote = true
+			hasKey = false
+		case ch == '=' && !hasKey:
+			cur.WriteByte(ch)
+			hasKey = true
+			canQuote = true
+		default:
+			cur.WriteByte(ch)
+			canQuote = false
+		}
+	}
+	if inQuote {
+		return nil, errors.Wrapf(ErrInvalidTag, "unterminated quote in %q", tagStr)
+	}
+	res = append(res, cur.String())
+	return res, nil
+}
@@ -0,0 +1,137 @@
+package slicetostruct
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	scannerType         = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType          = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// ConvertText is used for types which implement encoding.TextUnmarshaler and encoding.TextMarshaler,
+// when converter for type is not set.
+type ConvertText struct {
+}
+
+func (c *ConvertText) Set(value *ConvertValueParams) error {
+	fieldType := value.ReflectValue.Type()
+	if fieldType.Kind() == reflect.Ptr {
+		if value.Items[value.Index] == "" {
+			return nil
+		}
+		fieldType = fieldType.Elem()
+	}
+	v := reflect.New(fieldType)
+	unmarshaler, ok := v.Interface().(encoding.TextUnmarshaler)
+	if !ok {
+		return errors.Errorf("type does not implement encoding.TextUnmarshaler, %s", value.FieltType)
+	}
+	err := unmarshaler.UnmarshalText([]byte(value.Items[value.Index]))
+	if err != nil {
+		return errors.Wrap(err, "cant UnmarshalText")
+	}
+	elem(value.ReflectValue).Set(v.Elem())
+	return nil
+}
+
+func (c *ConvertText) Format(value *FormatValueParams) (string, error) {
+	v, ok := indirectInterface(*value.ReflectValue, textMarshalerType)
+	if !ok {
+		return "", nil
+	}
+	res, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return "", errors.Wrap(err, "cant MarshalText")
+	}
+	return string(res), nil
+}
+
+// ConvertScanner is used for types which implement sql.Scanner and driver.Valuer,
+// when converter for type is not set.
+type ConvertScanner struct {
+	params *Params
+}
+
+func (c *ConvertScanner) Set(value *ConvertValueParams) error {
+	if value.Items[value.Index] == "" {
+		return nil
+	}
+	fieldType := value.ReflectValue.Type()
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	v := reflect.New(fieldType)
+	scanner, ok := v.Interface().(sql.Scanner)
+	if !ok {
+		return errors.Errorf("type does not implement sql.Scanner, %s", value.FieltType)
+	}
+	err := scanner.Scan(value.Items[value.Index])
+	if err != nil {
+		return errors.Wrap(err, "cant Scan")
+	}
+	elem(value.ReflectValue).Set(v.Elem())
+	return nil
+}
+
+func (c *ConvertScanner) Format(value *FormatValueParams) (string, error) {
+	v, ok := indirectInterface(*value.ReflectValue, valuerType)
+	if !ok {
+		return "", nil
+	}
+	res, err := v.Interface().(driver.Valuer).Value()
+	if err != nil {
+		return "", errors.Wrap(err, "cant Value")
+	}
+	switch res := res.(type) {
+	case nil:
+		return "", nil
+	case int64:
+		return formatNumber(value.fieldOptions(c.params), strconv.FormatInt(res, 10)), nil
+	case float64:
+		return formatFloat(value.fieldOptions(c.params), res, 64), nil
+	case bool:
+		return formatBool(value.fieldOptions(c.params), res), nil
+	case []byte:
+		return string(res), nil
+	case string:
+		return res, nil
+	case time.Time:
+		return formatTime(value.fieldOptions(c.params), res)
+	default:
+		return "", errors.Errorf("unknown driver.Value %T", res)
+	}
+}
+
+// implements reports whether fieldType, pointer to it or its element implements iface.
+func implements(fieldType reflect.Type, iface reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType.Implements(iface) || reflect.PtrTo(fieldType).Implements(iface)
+}
+
+// indirectInterface returns value or its address to call methods of iface, false for nil pointer.
+func indirectInterface(v reflect.Value, iface reflect.Type) (reflect.Value, bool) {
+	if v.Kind() == reflect.Ptr {
+		return v, !v.IsNil()
+	}
+	if v.Type().Implements(iface) {
+		return v, true
+	}
+	if v.CanAddr() {
+		return v.Addr(), true
+	}
+	tmp := reflect.New(v.Type())
+	tmp.Elem().Set(v)
+	return tmp, true
+}
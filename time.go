@@ -0,0 +1,120 @@
+package slicetostruct
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/go-faster/errors"
+)
+
+type ConvertTime struct {
+	params *Params
+}
+
+func (c *ConvertTime) Set(value *ConvertValueParams) error {
+	t, err := parseTime(value.fieldOptions(c.params), value.Items[value.Index])
+	if err != nil {
+		return err
+	}
+	value.ReflectValue.Set(reflect.ValueOf(t))
+	return nil
+}
+
+func (c *ConvertTime) Format(value *FormatValueParams) (string, error) {
+	t, ok := value.ReflectValue.Interface().(time.Time)
+	if !ok {
+		return "", errors.Errorf("value is not time.Time, %s", value.FieltType)
+	}
+	return formatTime(value.fieldOptions(c.params), t)
+}
+
+type ConvertNullTime struct {
+	params *Params
+}
+
+func (c *ConvertNullTime) Set(value *ConvertValueParams) error {
+	if value.Items[value.Index] == "" {
+		return nil
+	}
+	t, err := parseTime(value.fieldOptions(c.params), value.Items[value.Index])
+	if err != nil {
+		return err
+	}
+	value.ReflectValue.Set(reflect.ValueOf(&t))
+	return nil
+}
+
+func (c *ConvertNullTime) Format(value *FormatValueParams) (string, error) {
+	if value.ReflectValue.IsNil() {
+		return "", nil
+	}
+	t, ok := value.ReflectValue.Elem().Interface().(time.Time)
+	if !ok {
+		return "", errors.Errorf("value is not time.Time, %s", value.FieltType)
+	}
+	return formatTime(value.fieldOptions(c.params), t)
+}
+
+var locations sync.Map
+
+// loadLocation is time.LoadLocation with cache, it reads zoneinfo on every call.
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cant time.LoadLocation, %s", name)
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+var defaultTimeLayouts = []string{defaultTimeLayout}
+
+// layouts returns time layouts of field, from tag or Params.TimeLayouts.
+func (o *fieldOptions) layouts() []string {
+	if o.timeLayouts != nil {
+		return o.timeLayouts
+	}
+	if o.params != nil && len(o.params.TimeLayouts) > 0 {
+		return o.params.TimeLayouts
+	}
+	return defaultTimeLayouts
+}
+
+// parseTime parses item by layouts of field in order, in location of tz option or UTC.
+func parseTime(o *fieldOptions, item string) (time.Time, error) {
+	if o.locationErr != nil {
+		return time.Time{}, o.locationErr
+	}
+	loc := time.UTC
+	if o.location != nil {
+		loc = o.location
+	}
+	layouts := o.layouts()
+	var err error
+	for _, layout := range layouts {
+		var t time.Time
+		t, err = time.ParseInLocation(layout, item, loc)
+		if err == nil {
+			return t, nil
+		}
+	}
+	if len(layouts) == 1 {
+		return time.Time{}, errors.Wrap(err, "cant time.Parse")
+	}
+	return time.Time{}, errors.Wrapf(err, "cant time.Parse %q, layouts %q", item, layouts)
+}
+
+// formatTime formats t by first layout of field.
+func formatTime(o *fieldOptions, t time.Time) (string, error) {
+	if o.locationErr != nil {
+		return "", o.locationErr
+	}
+	if o.location != nil {
+		t = t.In(o.location)
+	}
+	return t.Format(o.layouts()[0]), nil
+}